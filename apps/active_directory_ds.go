@@ -31,6 +31,11 @@ func (r MetricsReceiverActiveDirectoryDS) Type() string {
 	return "active_directory_ds"
 }
 
+// KnownMetrics returns the expected metrics of the active_directory_ds integration.
+func (MetricsReceiverActiveDirectoryDS) KnownMetrics() []string {
+	return thirdPartyAppMetrics["active_directory_ds"]
+}
+
 func (r MetricsReceiverActiveDirectoryDS) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	return []otel.ReceiverPipeline{confgenerator.ConvertGCMOtelExporterToOtlpExporter(otel.ReceiverPipeline{
 		Receiver: otel.Component{
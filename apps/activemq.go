@@ -34,6 +34,11 @@ func (r MetricsReceiverActivemq) Type() string {
 	return "activemq"
 }
 
+// KnownMetrics returns the expected metrics of the activemq integration.
+func (MetricsReceiverActivemq) KnownMetrics() []string {
+	return thirdPartyAppMetrics["activemq"]
+}
+
 func (r MetricsReceiverActivemq) Pipelines(_ context.Context) ([]otel.ReceiverPipeline, error) {
 
 	targetSystem := "activemq"
@@ -40,6 +40,11 @@ func (r MetricsReceiverAerospike) Type() string {
 	return "aerospike"
 }
 
+// KnownMetrics returns the expected metrics of the aerospike integration.
+func (MetricsReceiverAerospike) KnownMetrics() []string {
+	return thirdPartyAppMetrics["aerospike"]
+}
+
 const (
 	defaultAerospikeEndpoint     = "localhost:3000"
 	defaultCollectClusterMetrics = false // We assume the agent's running on each node
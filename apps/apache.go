@@ -35,6 +35,18 @@ func (r MetricsReceiverApache) Type() string {
 	return "apache"
 }
 
+// KnownMetrics returns the expected metrics of the apache integration.
+func (MetricsReceiverApache) KnownMetrics() []string {
+	return thirdPartyAppMetrics["apache"]
+}
+
+func (r MetricsReceiverApache) GetTargetAddress() string {
+	if r.ServerStatusURL == "" {
+		return confgenerator.TargetAddress(defaultServerStatusURL)
+	}
+	return confgenerator.TargetAddress(r.ServerStatusURL)
+}
+
 func (r MetricsReceiverApache) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	if r.ServerStatusURL == "" {
 		r.ServerStatusURL = defaultServerStatusURL
@@ -35,6 +35,11 @@ func (r MetricsReceiverCassandra) Type() string {
 	return "cassandra"
 }
 
+// KnownMetrics returns the expected metrics of the cassandra integration, and of the jvm integration if they are collected.
+func (r MetricsReceiverCassandra) KnownMetrics() []string {
+	return withJVMMetrics(r.MetricsReceiverSharedCollectJVM, thirdPartyAppMetrics["cassandra"])
+}
+
 func (r MetricsReceiverCassandra) Pipelines(_ context.Context) ([]otel.ReceiverPipeline, error) {
 	targetSystem := "cassandra"
 
@@ -0,0 +1,107 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel"
+	"github.com/GoogleCloudPlatform/ops-agent/internal/platform"
+)
+
+// MetricsReceiverContainerdStats collects per-container metrics of the containers run by containerd.
+// containerd reads them from the cgroup v2 files of each container, and serves them on its metrics
+// endpoint, which is enabled by setting "address" in the [metrics] section of its config.toml.
+type MetricsReceiverContainerdStats struct {
+	confgenerator.ConfigComponent       `yaml:",inline"`
+	confgenerator.MetricsReceiverShared `yaml:",inline"`
+
+	// Endpoint is the host:port of the containerd metrics endpoint.
+	Endpoint string `yaml:"endpoint" validate:"omitempty,hostname_port"`
+}
+
+const (
+	defaultContainerdStatsEndpoint = "localhost:1338"
+	containerdStatsMetricsPath     = "/v1/metrics"
+)
+
+func (r MetricsReceiverContainerdStats) Type() string {
+	return "containerd_stats"
+}
+
+func (r MetricsReceiverContainerdStats) GetTargetAddress() string {
+	if r.Endpoint == "" {
+		return defaultContainerdStatsEndpoint
+	}
+	return r.Endpoint
+}
+
+func (r MetricsReceiverContainerdStats) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
+	config := map[string]interface{}{
+		"config": map[string]interface{}{
+			"scrape_configs": []map[string]interface{}{
+				{
+					"job_name":        r.Type(),
+					"scrape_interval": r.CollectionIntervalString(),
+					"metrics_path":    containerdStatsMetricsPath,
+					"metric_relabel_configs": []map[string]interface{}{
+						{
+							// Only keep the cgroup stats of the containers, and not the metrics about containerd itself.
+							"source_labels": []string{"__name__"},
+							"regex":         "container_(cpu|memory|io|pids)_.+",
+							"action":        "keep",
+						},
+					},
+					"static_configs": []map[string]interface{}{
+						{
+							"targets": []string{r.GetTargetAddress()},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return []otel.ReceiverPipeline{confgenerator.ConvertGCMOtelExporterToOtlpExporter(otel.ReceiverPipeline{
+		Receiver: otel.Component{
+			Type:   "prometheus",
+			Config: config,
+		},
+		Processors: map[string][]otel.Component{"metrics": {
+			otel.MetricStartTime(),
+			// remove prometheus scraping meta-metrics
+			otel.MetricsFilter("exclude", "strict",
+				"scrape_samples_post_metric_relabeling",
+				"scrape_series_added",
+				"scrape_duration_seconds",
+				"scrape_samples_scraped",
+				"up",
+			),
+			otel.MetricsTransform(
+				otel.AddPrefix("workload.googleapis.com"),
+			),
+			otel.TransformationMetrics(
+				otel.SetScopeName("agent.googleapis.com/"+r.Type()),
+				otel.SetScopeVersion("1.0"),
+			),
+			otel.MetricsRemoveServiceAttributes(),
+		}},
+	}, ctx)}, nil
+}
+
+func init() {
+	confgenerator.MetricsReceiverTypes.RegisterType(func() confgenerator.MetricsReceiver { return &MetricsReceiverContainerdStats{} }, platform.Linux)
+}
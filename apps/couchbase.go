@@ -41,6 +41,11 @@ func (r MetricsReceiverCouchbase) Type() string {
 	return "couchbase"
 }
 
+// KnownMetrics returns the expected metrics of the couchbase integration.
+func (MetricsReceiverCouchbase) KnownMetrics() []string {
+	return thirdPartyAppMetrics["couchbase"]
+}
+
 // Pipelines will construct the prometheus receiver configuration
 func (r MetricsReceiverCouchbase) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	targets := []string{r.Endpoint}
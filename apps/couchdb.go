@@ -39,6 +39,18 @@ func (MetricsReceiverCouchdb) Type() string {
 	return "couchdb"
 }
 
+// KnownMetrics returns the expected metrics of the couchdb integration.
+func (MetricsReceiverCouchdb) KnownMetrics() []string {
+	return thirdPartyAppMetrics["couchdb"]
+}
+
+func (r MetricsReceiverCouchdb) GetTargetAddress() string {
+	if r.Endpoint == "" {
+		return confgenerator.TargetAddress(defaultCouchdbEndpoint)
+	}
+	return confgenerator.TargetAddress(r.Endpoint)
+}
+
 func (r MetricsReceiverCouchdb) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	if r.Endpoint == "" {
 		r.Endpoint = defaultCouchdbEndpoint
@@ -36,6 +36,14 @@ func (r MetricsReceiverDcgm) Type() string {
 	return "dcgm"
 }
 
+// KnownMetrics returns the expected metrics of the dcgm integration for the receiver version.
+func (r MetricsReceiverDcgm) KnownMetrics() []string {
+	if r.ReceiverVersion == "2" {
+		return thirdPartyAppMetrics["dcgm"]
+	}
+	return thirdPartyAppMetrics["dcgmv1"]
+}
+
 func (r MetricsReceiverDcgm) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	if r.Endpoint == "" {
 		r.Endpoint = defaultDcgmEndpoint
@@ -0,0 +1,87 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel"
+	"github.com/GoogleCloudPlatform/ops-agent/internal/platform"
+)
+
+// MetricsReceiverDockerStats collects per-container metrics from the Docker Engine API.
+// Containers run directly by containerd are not visible through the Docker API; they are covered by containerd_stats.
+type MetricsReceiverDockerStats struct {
+	confgenerator.ConfigComponent       `yaml:",inline"`
+	confgenerator.MetricsReceiverShared `yaml:",inline"`
+
+	// Endpoint is the address of the Docker Engine API, e.g. unix:///var/run/docker.sock or tcp://localhost:2375.
+	Endpoint string `yaml:"endpoint" validate:"omitempty,url"`
+	// ExcludedImages are the images of the containers to skip. They may be globs or regular expressions enclosed in slashes.
+	ExcludedImages []string `yaml:"excluded_images,flow" validate:"dive,required"`
+}
+
+const defaultDockerStatsEndpoint = "unix:///var/run/docker.sock"
+
+func (r MetricsReceiverDockerStats) Type() string {
+	return "docker_stats"
+}
+
+func (r MetricsReceiverDockerStats) GetTargetAddress() string {
+	if r.Endpoint == "" {
+		return confgenerator.TargetAddress(defaultDockerStatsEndpoint)
+	}
+	return confgenerator.TargetAddress(r.Endpoint)
+}
+
+func (r MetricsReceiverDockerStats) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
+	if r.Endpoint == "" {
+		r.Endpoint = defaultDockerStatsEndpoint
+	}
+
+	config := map[string]interface{}{
+		"collection_interval": r.CollectionIntervalString(),
+		"endpoint":            r.Endpoint,
+	}
+	if len(r.ExcludedImages) > 0 {
+		config["excluded_images"] = r.ExcludedImages
+	}
+
+	return []otel.ReceiverPipeline{confgenerator.ConvertGCMOtelExporterToOtlpExporter(otel.ReceiverPipeline{
+		Receiver: otel.Component{
+			Type:   "docker_stats",
+			Config: config,
+		},
+		Processors: map[string][]otel.Component{"metrics": {
+			otel.MetricStartTime(),
+			otel.MetricsTransform(
+				otel.AddPrefix("workload.googleapis.com"),
+			),
+			otel.TransformationMetrics(
+				otel.FlattenResourceAttribute("container.id", "container_id"),
+				otel.FlattenResourceAttribute("container.name", "container_name"),
+				otel.FlattenResourceAttribute("container.image.name", "image"),
+				otel.SetScopeName("agent.googleapis.com/"+r.Type()),
+				otel.SetScopeVersion("1.0"),
+			),
+			otel.MetricsRemoveServiceAttributes(),
+		}},
+	}, ctx)}, nil
+}
+
+func init() {
+	confgenerator.MetricsReceiverTypes.RegisterType(func() confgenerator.MetricsReceiver { return &MetricsReceiverDockerStats{} }, platform.Linux)
+}
@@ -17,6 +17,7 @@ package apps
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel"
@@ -44,6 +45,24 @@ func (r MetricsReceiverElasticsearch) Type() string {
 	return "elasticsearch"
 }
 
+// KnownMetrics returns the expected metrics of the elasticsearch and elasticsearch9 integrations, and the JVM metrics if they are collected.
+func (r MetricsReceiverElasticsearch) KnownMetrics() []string {
+	metrics := slices.Concat(thirdPartyAppMetrics["elasticsearch"], thirdPartyAppMetrics["elasticsearch9"])
+	if r.ShouldCollectJVMMetrics() {
+		for _, metric := range elasticsearchJVMMetrics {
+			metrics = append(metrics, "workload.googleapis.com/"+metric)
+		}
+	}
+	return metrics
+}
+
+func (r MetricsReceiverElasticsearch) GetTargetAddress() string {
+	if r.Endpoint == "" {
+		return confgenerator.TargetAddress(defaultElasticsearchEndpoint)
+	}
+	return confgenerator.TargetAddress(r.Endpoint)
+}
+
 func (r MetricsReceiverElasticsearch) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	if r.Endpoint == "" {
 		r.Endpoint = defaultElasticsearchEndpoint
@@ -97,22 +116,23 @@ func (r MetricsReceiverElasticsearch) Pipelines(ctx context.Context) ([]otel.Rec
 	}, ctx)}, nil
 }
 
-func (r MetricsReceiverElasticsearch) skipJVMMetricsConfig(metricsConfig map[string]interface{}) {
-	jvmMetrics := []string{
-		"jvm.classes.loaded",
-		"jvm.gc.collections.count",
-		"jvm.gc.collections.elapsed",
-		"jvm.memory.heap.max",
-		"jvm.memory.heap.used",
-		"jvm.memory.heap.committed",
-		"jvm.memory.nonheap.used",
-		"jvm.memory.nonheap.committed",
-		"jvm.memory.pool.max",
-		"jvm.memory.pool.used",
-		"jvm.threads.count",
-	}
+// elasticsearchJVMMetrics are the JVM metrics that the elasticsearch receiver collects, which are controlled by collect_jvm_metrics.
+var elasticsearchJVMMetrics = []string{
+	"jvm.classes.loaded",
+	"jvm.gc.collections.count",
+	"jvm.gc.collections.elapsed",
+	"jvm.memory.heap.max",
+	"jvm.memory.heap.used",
+	"jvm.memory.heap.committed",
+	"jvm.memory.nonheap.used",
+	"jvm.memory.nonheap.committed",
+	"jvm.memory.pool.max",
+	"jvm.memory.pool.used",
+	"jvm.threads.count",
+}
 
-	for _, metric := range jvmMetrics {
+func (r MetricsReceiverElasticsearch) skipJVMMetricsConfig(metricsConfig map[string]interface{}) {
+	for _, metric := range elasticsearchJVMMetrics {
 		metricsConfig[metric] = map[string]bool{
 			"enabled": false,
 		}
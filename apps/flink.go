@@ -31,6 +31,11 @@ func (MetricsReceiverFlink) Type() string {
 	return "flink"
 }
 
+// KnownMetrics returns the expected metrics of the flink integration.
+func (MetricsReceiverFlink) KnownMetrics() []string {
+	return thirdPartyAppMetrics["flink"]
+}
+
 const defaultFlinkEndpoint = "http://localhost:8081"
 
 func (r MetricsReceiverFlink) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
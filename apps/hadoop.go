@@ -34,6 +34,11 @@ func (r MetricsReceiverHadoop) Type() string {
 	return "hadoop"
 }
 
+// KnownMetrics returns the expected metrics of the hadoop integration, and of the jvm integration if they are collected.
+func (r MetricsReceiverHadoop) KnownMetrics() []string {
+	return withJVMMetrics(r.MetricsReceiverSharedCollectJVM, thirdPartyAppMetrics["hadoop"])
+}
+
 func (r MetricsReceiverHadoop) Pipelines(_ context.Context) ([]otel.ReceiverPipeline, error) {
 	targetSystem := "hadoop"
 	if r.MetricsReceiverSharedCollectJVM.ShouldCollectJVMMetrics() {
@@ -34,6 +34,11 @@ func (r MetricsReceiverHbase) Type() string {
 	return "hbase"
 }
 
+// KnownMetrics returns the expected metrics of the hbase integration, and of the jvm integration if they are collected.
+func (r MetricsReceiverHbase) KnownMetrics() []string {
+	return withJVMMetrics(r.MetricsReceiverSharedCollectJVM, thirdPartyAppMetrics["hbase"])
+}
+
 func (r MetricsReceiverHbase) Pipelines(_ context.Context) ([]otel.ReceiverPipeline, error) {
 	targetSystem := "hbase"
 	if r.MetricsReceiverSharedCollectJVM.ShouldCollectJVMMetrics() {
@@ -16,9 +16,14 @@ package apps
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel/ottl"
 	"github.com/GoogleCloudPlatform/ops-agent/internal/platform"
 )
 
@@ -27,9 +32,159 @@ type MetricsReceiverHostmetrics struct {
 
 	confgenerator.MetricsReceiverShared `yaml:",inline"`
 
+	// Scrapers overrides the settings of individual scrapers, keyed by scraper name.
+	Scrapers map[string]*HostmetricsScraper `yaml:"scrapers,omitempty" validate:"dive,keys,oneof=cpu disk filesystem load memory network paging process processes,endkeys,required"`
+
+	// ProcessMetrics selects and groups the processes whose metrics are collected by the process scraper.
+	ProcessMetrics *HostmetricsProcessMetrics `yaml:"process_metrics,omitempty"`
+
 	disableGPUMetrics bool `yaml:"-"`
 }
 
+// HostmetricsProcessMetrics configures the per-process metrics of the hostmetrics receiver.
+type HostmetricsProcessMetrics struct {
+	// Include keeps only the processes that match. By default, all the processes are kept.
+	Include *HostmetricsProcessMatch `yaml:"include,omitempty"`
+	Exclude *HostmetricsProcessMatch `yaml:"exclude,omitempty"`
+	// GroupByName sums the metrics of the processes with the same name, instead of reporting each process.
+	GroupByName bool `yaml:"group_by_name,omitempty"`
+}
+
+// A HostmetricsProcessMatch matches the processes that match all of its fields.
+// A list matches if the process attribute is equal to any of its values.
+type HostmetricsProcessMatch struct {
+	Names           []string `yaml:"names,omitempty,flow" validate:"required_without_all=ExecutablePaths CommandLine Owners,dive,required"`
+	ExecutablePaths []string `yaml:"executable_paths,omitempty,flow" validate:"dive,required"`
+	// CommandLine is a regular expression that matches anywhere in the command line, unless it is anchored with ^ or $.
+	CommandLine string   `yaml:"command_line,omitempty"`
+	Owners      []string `yaml:"owners,omitempty,flow" validate:"dive,required"`
+}
+
+// condition returns the OTTL condition on the resource attributes of a process that matches m.
+func (m HostmetricsProcessMatch) condition() (ottl.Value, error) {
+	// $ needs to be escaped in the collector config, like in exclude_metrics.
+	escape := func(s string) string {
+		return strings.ReplaceAll(s, "$", "$$")
+	}
+	var conditions []ottl.Value
+	anyOf := func(attribute string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		var equals []ottl.Value
+		for _, v := range values {
+			equals = append(equals, ottl.Equals(ottl.LValue{"attributes", attribute}, ottl.StringLiteral(escape(v))))
+		}
+		conditions = append(conditions, ottl.Or(equals...))
+	}
+	anyOf("process.executable.name", m.Names)
+	anyOf("process.executable.path", m.ExecutablePaths)
+	if m.CommandLine != "" {
+		if _, err := regexp.Compile(m.CommandLine); err != nil {
+			return nil, fmt.Errorf("invalid command_line %q: %w", m.CommandLine, err)
+		}
+		conditions = append(conditions, ottl.IsMatch(ottl.LValue{"attributes", "process.command_line"}, escape(m.CommandLine)))
+	}
+	anyOf("process.owner", m.Owners)
+	return ottl.And(conditions...), nil
+}
+
+// filter returns a processor that drops the processes that are not included or are excluded, or nil if all the processes are kept.
+func (p HostmetricsProcessMetrics) filter() (*otel.Component, error) {
+	var dropped []ottl.Value
+	if p.Include != nil {
+		include, err := p.Include.condition()
+		if err != nil {
+			return nil, fmt.Errorf("include: %w", err)
+		}
+		dropped = append(dropped, ottl.Not(include))
+	}
+	if p.Exclude != nil {
+		exclude, err := p.Exclude.condition()
+		if err != nil {
+			return nil, fmt.Errorf("exclude: %w", err)
+		}
+		dropped = append(dropped, exclude)
+	}
+	if len(dropped) == 0 {
+		return nil, nil
+	}
+	// Only the resources of the process scraper have a process.pid attribute.
+	filter := otel.Filter("metrics", "resource", []ottl.Value{ottl.And(
+		ottl.IsNotNil(ottl.LValue{"attributes", "process.pid"}),
+		ottl.Or(dropped...),
+	)})
+	return &filter, nil
+}
+
+// hostmetricsProcessCumulativeMetrics are the cumulative metrics of the process scraper, as named before the transforms.
+var hostmetricsProcessCumulativeMetrics = []string{
+	"process.cpu.time",
+	"process.disk.read_io",
+	"process.disk.write_io",
+}
+
+// groupByName returns the transforms that sum the renamed process metrics over the processes with the same command.
+// The cumulative metrics are only summed while they are deltas (see groupByNameProcessors), since their sum would
+// decrease when a process exits, which Cloud Monitoring rejects.
+func (p HostmetricsProcessMetrics) groupByName(windows bool) []map[string]interface{} {
+	if !p.GroupByName {
+		return nil
+	}
+	transforms := []map[string]interface{}{
+		otel.UpdateMetric("processes/cpu_time", otel.AggregateLabels("sum", "command", "process", "user_or_syst")),
+		otel.UpdateMetric("processes/disk/read_bytes_count", otel.AggregateLabels("sum", "command", "process")),
+		otel.UpdateMetric("processes/disk/write_bytes_count", otel.AggregateLabels("sum", "command", "process")),
+		otel.UpdateMetric("processes/rss_usage", otel.AggregateLabels("sum", "command", "process")),
+		otel.UpdateMetric("processes/vm_usage", otel.AggregateLabels("sum", "command", "process")),
+	}
+	if windows {
+		transforms = append(transforms, otel.UpdateMetric("processes/windows/handles", otel.AggregateLabels("sum", "command", "process")))
+	}
+	return transforms
+}
+
+// groupByNameProcessors returns the processors that run before and after the transforms of the process scraper.
+// The cumulative metrics of each process are converted to deltas before they are summed, and the sums are
+// accumulated again afterwards, so that the grouped metrics keep increasing when a process exits.
+func (p HostmetricsProcessMetrics) groupByNameProcessors() (before, after []otel.Component) {
+	if !p.GroupByName {
+		return nil, nil
+	}
+	return []otel.Component{otel.CumulativeToDelta(hostmetricsProcessCumulativeMetrics...)}, []otel.Component{otel.DeltaToCumulative()}
+}
+
+// A HostmetricsScraper configures one of the scrapers of the hostmetrics receiver.
+type HostmetricsScraper struct {
+	// Enabled defaults to true.
+	Enabled *bool `yaml:"enabled,omitempty"`
+	// CollectionInterval defaults to the collection_interval of the receiver.
+	CollectionInterval string `yaml:"collection_interval,omitempty" validate:"omitempty,duration=10s"`
+}
+
+// scraperIntervals groups the enabled scrapers by their collection interval.
+// It returns the intervals in order, starting with the interval of the receiver.
+func (r MetricsReceiverHostmetrics) scraperIntervals() ([]string, map[string][]string) {
+	intervals := []string{r.CollectionIntervalString()}
+	scrapers := map[string][]string{}
+	for _, name := range confgenerator.GetSortedKeys(hostmetricsScraperMetrics) {
+		interval := r.CollectionIntervalString()
+		if s := r.Scrapers[name]; s != nil {
+			if s.Enabled != nil && !*s.Enabled {
+				continue
+			}
+			if s.CollectionInterval != "" {
+				interval = s.CollectionInterval
+			}
+		}
+		if !slices.Contains(intervals, interval) {
+			intervals = append(intervals, interval)
+		}
+		scrapers[interval] = append(scrapers[interval], name)
+	}
+	return intervals, scrapers
+}
+
 func (r MetricsReceiverHostmetrics) Type() string {
 	return "hostmetrics"
 }
@@ -284,50 +439,74 @@ func (r MetricsReceiverHostmetrics) Pipelines(ctx context.Context) ([]otel.Recei
 			),
 		)
 	}
+	var processFilter *otel.Component
+	if r.ProcessMetrics != nil {
+		var err error
+		if processFilter, err = r.ProcessMetrics.filter(); err != nil {
+			return nil, fmt.Errorf("process_metrics: %w", err)
+		}
+		transforms = append(transforms, r.ProcessMetrics.groupByName(p.Type == platform.Windows)...)
+	}
 	transforms = append(transforms, otel.AddPrefix("agent.googleapis.com"))
-	pipelines := []otel.ReceiverPipeline{confgenerator.ConvertGCMSystemExporterToOtlpExporter(otel.ReceiverPipeline{
-		Receiver: otel.Component{
-			Type: "hostmetrics",
-			Config: map[string]interface{}{
-				"collection_interval": r.CollectionIntervalString(),
-				"scrapers": map[string]interface{}{
-					"cpu":        struct{}{},
-					"load":       struct{}{},
-					"memory":     struct{}{},
-					"disk":       struct{}{},
-					"filesystem": struct{}{},
-					"network":    struct{}{},
-					"paging":     struct{}{},
-					"process":    processConfig,
-					"processes":  struct{}{},
+	var pipelines []otel.ReceiverPipeline
+	intervals, scrapers := r.scraperIntervals()
+	for _, interval := range intervals {
+		if len(scrapers[interval]) == 0 {
+			continue
+		}
+		scrapersConfig := map[string]interface{}{}
+		for _, name := range scrapers[interval] {
+			scrapersConfig[name] = struct{}{}
+		}
+		var processors, beforeTransforms, afterTransforms []otel.Component
+		if _, ok := scrapersConfig["process"]; ok {
+			scrapersConfig["process"] = processConfig
+			if processFilter != nil {
+				processors = append(processors, *processFilter)
+			}
+			if r.ProcessMetrics != nil {
+				beforeTransforms, afterTransforms = r.ProcessMetrics.groupByNameProcessors()
+			}
+		}
+		pipelines = append(pipelines, confgenerator.ConvertGCMSystemExporterToOtlpExporter(otel.ReceiverPipeline{
+			Receiver: otel.Component{
+				Type: "hostmetrics",
+				Config: map[string]interface{}{
+					"collection_interval": interval,
+					"scrapers":            scrapersConfig,
 				},
 			},
-		},
-		ExporterTypes: map[string]otel.ExporterType{
-			"metrics": otel.System,
-		},
-		Processors: map[string][]otel.Component{"metrics": {
-			{
-				// perform custom transformations that aren't supported by the metricstransform processor
-				Type: "agentmetrics",
-				Config: map[string]interface{}{
-					// https://github.com/GoogleCloudPlatform/opentelemetry-operations-collector/blob/master/processor/agentmetricsprocessor/agentmetricsprocessor.go#L58
-					"blank_label_metrics": []string{
-						"system.cpu.utilization",
+			ExporterTypes: map[string]otel.ExporterType{
+				"metrics": otel.System,
+			},
+			Processors: map[string][]otel.Component{"metrics": slices.Concat(
+				processors,
+				[]otel.Component{
+					{
+						// perform custom transformations that aren't supported by the metricstransform processor
+						Type: "agentmetrics",
+						Config: map[string]interface{}{
+							// https://github.com/GoogleCloudPlatform/opentelemetry-operations-collector/blob/master/processor/agentmetricsprocessor/agentmetricsprocessor.go#L58
+							"blank_label_metrics": []string{
+								"system.cpu.utilization",
+							},
+						},
 					},
+					otel.MetricsFilter(
+						"exclude",
+						"strict",
+						"system.network.dropped",
+						"system.filesystem.inodes.usage",
+						"system.paging.faults",
+						"system.disk.operation_time",
+					),
 				},
-			},
-			otel.MetricsFilter(
-				"exclude",
-				"strict",
-				"system.network.dropped",
-				"system.filesystem.inodes.usage",
-				"system.paging.faults",
-				"system.disk.operation_time",
-			),
-			otel.MetricsTransform(transforms...),
-		}},
-	}, ctx)}
+				beforeTransforms,
+				[]otel.Component{otel.MetricsTransform(transforms...)},
+				afterTransforms,
+			)},
+		}, ctx))
+	}
 
 	if p.HasNvidiaGpu && !r.disableGPUMetrics {
 		pipelines = append(pipelines, confgenerator.ConvertGCMSystemExporterToOtlpExporter(otel.ReceiverPipeline{
@@ -369,14 +548,86 @@ func (r MetricsReceiverHostmetrics) Pipelines(ctx context.Context) ([]otel.Recei
 	return pipelines, nil
 }
 
+// hostmetricsGPUMetrics are the metrics collected from NVIDIA GPUs.
+var hostmetricsGPUMetrics = []string{
+	"agent.googleapis.com/gpu/utilization",
+	"agent.googleapis.com/gpu/memory/bytes_used",
+	"agent.googleapis.com/gpu/processes/utilization",
+	"agent.googleapis.com/gpu/processes/max_bytes_used",
+}
+
+// hostmetricsScraperMetrics maps the scrapers of the hostmetrics receiver to the metrics they collect,
+// as listed in integration_test/ops_agent_test/agent_metrics/metadata.yaml.
+// processes/windows/handles is only collected on Windows.
+var hostmetricsScraperMetrics = map[string][]string{
+	"cpu": {
+		"agent.googleapis.com/cpu/usage_time",
+		"agent.googleapis.com/cpu/utilization",
+	},
+	"disk": {
+		"agent.googleapis.com/disk/io_time",
+		"agent.googleapis.com/disk/merged_operations",
+		"agent.googleapis.com/disk/operation_count",
+		"agent.googleapis.com/disk/operation_time",
+		"agent.googleapis.com/disk/pending_operations",
+		"agent.googleapis.com/disk/read_bytes_count",
+		"agent.googleapis.com/disk/weighted_io_time",
+		"agent.googleapis.com/disk/write_bytes_count",
+	},
+	"filesystem": {
+		"agent.googleapis.com/disk/bytes_used",
+		"agent.googleapis.com/disk/percent_used",
+	},
+	"load": {
+		"agent.googleapis.com/cpu/load_15m",
+		"agent.googleapis.com/cpu/load_1m",
+		"agent.googleapis.com/cpu/load_5m",
+	},
+	"memory": {
+		"agent.googleapis.com/memory/bytes_used",
+		"agent.googleapis.com/memory/percent_used",
+	},
+	"network": {
+		"agent.googleapis.com/interface/errors",
+		"agent.googleapis.com/interface/packets",
+		"agent.googleapis.com/interface/traffic",
+		"agent.googleapis.com/network/tcp_connections",
+	},
+	"paging": {
+		"agent.googleapis.com/pagefile/percent_used",
+		"agent.googleapis.com/swap/bytes_used",
+		"agent.googleapis.com/swap/io",
+		"agent.googleapis.com/swap/percent_used",
+	},
+	"process": {
+		"agent.googleapis.com/processes/cpu_time",
+		"agent.googleapis.com/processes/disk/read_bytes_count",
+		"agent.googleapis.com/processes/disk/write_bytes_count",
+		"agent.googleapis.com/processes/rss_usage",
+		"agent.googleapis.com/processes/vm_usage",
+		"agent.googleapis.com/processes/windows/handles",
+	},
+	"processes": {
+		"agent.googleapis.com/processes/count_by_state",
+		"agent.googleapis.com/processes/fork_count",
+	},
+}
+
+// KnownMetrics returns the metrics that the enabled scrapers can collect on any platform.
+func (r MetricsReceiverHostmetrics) KnownMetrics() []string {
+	var metrics []string
+	intervals, scrapers := r.scraperIntervals()
+	for _, interval := range intervals {
+		for _, name := range scrapers[interval] {
+			metrics = append(metrics, hostmetricsScraperMetrics[name]...)
+		}
+	}
+	return append(metrics, hostmetricsGPUMetrics...)
+}
+
 func (r MetricsReceiverHostmetrics) MergeMetricsProcessor(p confgenerator.MetricsProcessor) (confgenerator.MetricsReceiver, bool) {
 	if ep, ok := p.(*MetricsProcessorExcludeMetrics); ok {
-		r.disableGPUMetrics = r.disableGPUMetrics || ep.AllMetricsExcluded(
-			"agent.googleapis.com/gpu/utilization",
-			"agent.googleapis.com/gpu/memory/bytes_used",
-			"agent.googleapis.com/gpu/processes/utilization",
-			"agent.googleapis.com/gpu/processes/max_bytes_used",
-		)
+		r.disableGPUMetrics = r.disableGPUMetrics || ep.AllMetricsExcluded(hostmetricsGPUMetrics...)
 	}
 	// p could have patterns to filter out metrics from other pipelines, so
 	// returns false to get p added to the final configuration
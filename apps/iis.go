@@ -37,6 +37,14 @@ func (r MetricsReceiverIis) Type() string {
 	return "iis"
 }
 
+// KnownMetrics returns the expected metrics of the iis integration for the receiver version.
+func (r MetricsReceiverIis) KnownMetrics() []string {
+	if r.ReceiverVersion == "2" {
+		return thirdPartyAppMetrics["iis"]
+	}
+	return thirdPartyAppMetrics["iisv1"]
+}
+
 func (r MetricsReceiverIis) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	if r.ReceiverVersion == "2" {
 		return []otel.ReceiverPipeline{confgenerator.ConvertGCMOtelExporterToOtlpExporter(otel.ReceiverPipeline{
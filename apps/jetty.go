@@ -34,6 +34,11 @@ func (r MetricsReceiverJetty) Type() string {
 	return "jetty"
 }
 
+// KnownMetrics returns the expected metrics of the jetty integration, and of the jvm integration if they are collected.
+func (r MetricsReceiverJetty) KnownMetrics() []string {
+	return withJVMMetrics(r.MetricsReceiverSharedCollectJVM, thirdPartyAppMetrics["jetty"])
+}
+
 func (r MetricsReceiverJetty) Pipelines(_ context.Context) ([]otel.ReceiverPipeline, error) {
 	targetSystem := "jetty"
 	if r.MetricsReceiverSharedCollectJVM.ShouldCollectJVMMetrics() {
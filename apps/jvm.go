@@ -16,6 +16,7 @@ package apps
 
 import (
 	"context"
+	"slices"
 
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel"
@@ -33,6 +34,19 @@ func (r MetricsReceiverJVM) Type() string {
 	return "jvm"
 }
 
+// KnownMetrics returns the expected metrics of the jvm integration.
+func (MetricsReceiverJVM) KnownMetrics() []string {
+	return thirdPartyAppMetrics["jvm"]
+}
+
+// withJVMMetrics appends the expected metrics of the jvm integration to metrics, if the receiver collects them.
+func withJVMMetrics(c confgenerator.MetricsReceiverSharedCollectJVM, metrics []string) []string {
+	if !c.ShouldCollectJVMMetrics() {
+		return metrics
+	}
+	return slices.Concat(metrics, thirdPartyAppMetrics["jvm"])
+}
+
 func (r MetricsReceiverJVM) Pipelines(_ context.Context) ([]otel.ReceiverPipeline, error) {
 	return r.MetricsReceiverSharedJVM.
 		WithDefaultEndpoint(defaultJVMEndpoint).
@@ -34,6 +34,11 @@ func (r MetricsReceiverKafka) Type() string {
 	return "kafka"
 }
 
+// KnownMetrics returns the expected metrics of the kafka integration, and of the jvm integration if they are collected.
+func (r MetricsReceiverKafka) KnownMetrics() []string {
+	return withJVMMetrics(r.MetricsReceiverSharedCollectJVM, thirdPartyAppMetrics["kafka"])
+}
+
 func (r MetricsReceiverKafka) Pipelines(_ context.Context) ([]otel.ReceiverPipeline, error) {
 	targetSystem := "kafka"
 	return r.MetricsReceiverSharedJVM.
@@ -35,6 +35,18 @@ func (r MetricsReceiverMemcached) Type() string {
 	return "memcached"
 }
 
+// KnownMetrics returns the expected metrics of the memcached integration.
+func (MetricsReceiverMemcached) KnownMetrics() []string {
+	return thirdPartyAppMetrics["memcached"]
+}
+
+func (r MetricsReceiverMemcached) GetTargetAddress() string {
+	if r.Endpoint == "" {
+		return confgenerator.TargetAddress(defaultMemcachedTCPEndpoint)
+	}
+	return confgenerator.TargetAddress(r.Endpoint)
+}
+
 func (r MetricsReceiverMemcached) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	if r.Endpoint == "" {
 		r.Endpoint = defaultMemcachedTCPEndpoint
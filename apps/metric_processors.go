@@ -17,11 +17,16 @@ package apps
 import (
 	"context"
 	"fmt"
+	"log"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel/ottl"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/resourcedetector"
+	"github.com/GoogleCloudPlatform/ops-agent/internal/platform"
 )
 
 type MetricsProcessorExcludeMetrics struct {
@@ -48,6 +53,11 @@ func (p MetricsProcessorExcludeMetrics) Processors(_ context.Context) ([]otel.Co
 	)}, nil
 }
 
+// ValidateGMP allows exclude_metrics to be used with Prometheus metrics, since it only drops whole metrics.
+func (p MetricsProcessorExcludeMetrics) ValidateGMP() error {
+	return nil
+}
+
 // globToRegex converts metrics glob patterns to regex patterns
 func globToRegex(glob string) string {
 	var literals []string
@@ -58,6 +68,28 @@ func globToRegex(glob string) string {
 
 }
 
+// ExcludesMetric returns true if one of MetricsPattern matches metric.
+func (p MetricsProcessorExcludeMetrics) ExcludesMetric(metric string) bool {
+	return p.AllMetricsExcluded(metric)
+}
+
+// UnmatchedPatterns returns the patterns in MetricsPattern that match none of metrics.
+func (p MetricsProcessorExcludeMetrics) UnmatchedPatterns(metrics ...string) []string {
+	return unmatchedPatterns(p.MetricsPattern, metrics)
+}
+
+// unmatchedPatterns returns the glob patterns that match none of metrics.
+func unmatchedPatterns(patterns, metrics []string) []string {
+	var unmatched []string
+	for _, glob := range patterns {
+		r := regexp.MustCompile(globToRegex(glob))
+		if !slices.ContainsFunc(metrics, r.MatchString) {
+			unmatched = append(unmatched, glob)
+		}
+	}
+	return unmatched
+}
+
 // AllMetricsExcluded checks if its MetricsPattern list can match all of the
 // input metrics which would indicate all of the metrics will be excluded
 func (p MetricsProcessorExcludeMetrics) AllMetricsExcluded(metrics ...string) bool {
@@ -73,6 +105,301 @@ OUTER:
 	return true
 }
 
+// A MetricsProcessorFilterMetrics drops individual data points of the metrics matching MetricsPattern, based on their labels.
+type MetricsProcessorFilterMetrics struct {
+	confgenerator.ConfigComponent `yaml:",inline"`
+
+	// Action is "exclude" (the default) to drop the matching data points, or "include" to drop all the other data points of the selected metrics.
+	Action         string   `yaml:"action,omitempty" validate:"omitempty,oneof=include exclude"`
+	MetricsPattern []string `yaml:"metrics_pattern,flow" validate:"required,dive,required"`
+	// Labels maps label keys to glob patterns. A data point matches if all of the labels match; missing labels never match.
+	Labels map[string]string `yaml:"labels" validate:"required,dive,keys,required,endkeys"`
+}
+
+func (p MetricsProcessorFilterMetrics) Type() string {
+	return "filter_metrics"
+}
+
+func (p MetricsProcessorFilterMetrics) Processors(_ context.Context) ([]otel.Component, error) {
+	// $ needs to be escaped in the collector config, like in exclude_metrics.
+	escape := func(glob string) string {
+		return strings.ReplaceAll(globToRegex(glob), "$", "$$")
+	}
+	var metricConditions []ottl.Value
+	for _, glob := range p.MetricsPattern {
+		metricConditions = append(metricConditions, ottl.IsMatch(ottl.RValue("metric.name"), escape(glob)))
+	}
+	var labelConditions []ottl.Value
+	for _, k := range confgenerator.GetSortedKeys(p.Labels) {
+		labelConditions = append(labelConditions, ottl.IsMatch(ottl.LValue{"attributes", k}, escape(p.Labels[k])))
+	}
+	labelCondition := ottl.And(labelConditions...)
+	if p.Action == "include" {
+		labelCondition = ottl.Not(labelCondition)
+	}
+	return []otel.Component{otel.MetricsOTTLFilter(
+		[]string{},
+		[]string{ottl.And(ottl.Or(metricConditions...), labelCondition).String()},
+	)}, nil
+}
+
+// ExcludesMetric returns false, because filter_metrics only drops the data points that match its labels.
+func (p MetricsProcessorFilterMetrics) ExcludesMetric(metric string) bool {
+	return false
+}
+
+// UnmatchedPatterns returns the patterns in MetricsPattern that match none of metrics.
+func (p MetricsProcessorFilterMetrics) UnmatchedPatterns(metrics ...string) []string {
+	return unmatchedPatterns(p.MetricsPattern, metrics)
+}
+
+// ValidateGMP rejects conditions on the labels that are moved to the resource of Prometheus metrics.
+func (p MetricsProcessorFilterMetrics) ValidateGMP() error {
+	for _, k := range gmpResourceLabels {
+		if _, ok := p.Labels[k]; ok {
+			return fmt.Errorf("filter_metrics cannot filter on the %q label of Prometheus metrics", k)
+		}
+	}
+	return nil
+}
+
+// A MetricsProcessorModifyMetrics renames metrics and changes their labels and values, using the metricstransform processor.
+type MetricsProcessorModifyMetrics struct {
+	confgenerator.ConfigComponent `yaml:",inline"`
+
+	// Metrics are applied in order, so later entries see the names set by earlier ones.
+	Metrics []ModifyMetric `yaml:"metrics" validate:"required,dive"`
+}
+
+// A ModifyMetric applies Operations to the metrics named MetricName, and optionally renames them.
+type ModifyMetric struct {
+	// MetricName is the name of the metrics to modify. It may be a glob pattern, unless NewName is set.
+	MetricName string                  `yaml:"metric_name" validate:"required"`
+	NewName    string                  `yaml:"new_name,omitempty"`
+	Operations []ModifyMetricOperation `yaml:"operations,omitempty" validate:"required_without=NewName,dive"`
+}
+
+// A ModifyMetricOperation is a single change to a metric. Exactly one of its fields must be set.
+type ModifyMetricOperation struct {
+	AddLabel          *ModifyMetricLabel             `yaml:"add_label,omitempty" validate:"required_without_all=RenameLabel RenameLabelValues DeleteLabelValue AggregateLabels ScaleValue,excluded_with=RenameLabel RenameLabelValues DeleteLabelValue AggregateLabels ScaleValue"`
+	RenameLabel       *ModifyMetricRenameLabel       `yaml:"rename_label,omitempty" validate:"excluded_with=AddLabel RenameLabelValues DeleteLabelValue AggregateLabels ScaleValue"`
+	RenameLabelValues *ModifyMetricRenameLabelValues `yaml:"rename_label_values,omitempty" validate:"excluded_with=AddLabel RenameLabel DeleteLabelValue AggregateLabels ScaleValue"`
+	// DeleteLabelValue drops the data points whose label has the given value.
+	DeleteLabelValue *ModifyMetricLabel `yaml:"delete_label_value,omitempty" validate:"excluded_with=AddLabel RenameLabel RenameLabelValues AggregateLabels ScaleValue"`
+	// AggregateLabels removes all the labels except Labels, and aggregates the data points that become identical.
+	AggregateLabels *ModifyMetricAggregateLabels `yaml:"aggregate_labels,omitempty" validate:"excluded_with=AddLabel RenameLabel RenameLabelValues DeleteLabelValue ScaleValue"`
+	// ScaleValue multiplies the values of the data points.
+	ScaleValue *float64 `yaml:"scale_value,omitempty" validate:"excluded_with=AddLabel RenameLabel RenameLabelValues DeleteLabelValue AggregateLabels"`
+}
+
+type ModifyMetricLabel struct {
+	Label string `yaml:"label" validate:"required"`
+	Value string `yaml:"value" validate:"required"`
+}
+
+type ModifyMetricRenameLabel struct {
+	Label    string `yaml:"label" validate:"required"`
+	NewLabel string `yaml:"new_label" validate:"required,nefield=Label"`
+}
+
+type ModifyMetricRenameLabelValues struct {
+	Label string `yaml:"label" validate:"required"`
+	// Values maps old label values to new ones.
+	Values map[string]string `yaml:"values" validate:"required"`
+}
+
+type ModifyMetricAggregateLabels struct {
+	Labels          []string `yaml:"labels,flow" validate:"dive,required"`
+	AggregationType string   `yaml:"aggregation_type" validate:"required,oneof=sum mean max min"`
+}
+
+func (p MetricsProcessorModifyMetrics) Type() string {
+	return "modify_metrics"
+}
+
+// operation returns the metricstransform operation for o.
+func (o ModifyMetricOperation) operation() (map[string]interface{}, error) {
+	switch {
+	case o.AddLabel != nil:
+		return otel.AddLabel(o.AddLabel.Label, o.AddLabel.Value), nil
+	case o.RenameLabel != nil:
+		return otel.RenameLabel(o.RenameLabel.Label, o.RenameLabel.NewLabel), nil
+	case o.RenameLabelValues != nil:
+		return otel.RenameLabelValues(o.RenameLabelValues.Label, o.RenameLabelValues.Values), nil
+	case o.DeleteLabelValue != nil:
+		return otel.DeleteLabelValue(o.DeleteLabelValue.Label, o.DeleteLabelValue.Value), nil
+	case o.AggregateLabels != nil:
+		return otel.AggregateLabels(o.AggregateLabels.AggregationType, o.AggregateLabels.Labels...), nil
+	case o.ScaleValue != nil:
+		return otel.ScaleValue(*o.ScaleValue), nil
+	}
+	return nil, fmt.Errorf("operation is empty")
+}
+
+// labels returns the labels that o changes.
+func (o ModifyMetricOperation) labels() []string {
+	switch {
+	case o.AddLabel != nil:
+		return []string{o.AddLabel.Label}
+	case o.RenameLabel != nil:
+		return []string{o.RenameLabel.Label, o.RenameLabel.NewLabel}
+	case o.RenameLabelValues != nil:
+		return []string{o.RenameLabelValues.Label}
+	case o.DeleteLabelValue != nil:
+		return []string{o.DeleteLabelValue.Label}
+	}
+	return nil
+}
+
+func (p MetricsProcessorModifyMetrics) Processors(_ context.Context) ([]otel.Component, error) {
+	var transforms []map[string]interface{}
+	for _, m := range p.Metrics {
+		var operations []map[string]interface{}
+		for i, o := range m.Operations {
+			operation, err := o.operation()
+			if err != nil {
+				return nil, fmt.Errorf("operation %d of metric %q: %w", i, m.MetricName, err)
+			}
+			operations = append(operations, operation)
+		}
+		switch {
+		case !strings.Contains(m.MetricName, "*"):
+			if m.NewName != "" {
+				transforms = append(transforms, otel.RenameMetric(m.MetricName, m.NewName, operations...))
+			} else {
+				transforms = append(transforms, otel.UpdateMetric(m.MetricName, operations...))
+			}
+		case m.NewName != "":
+			return nil, fmt.Errorf("metric %q: new_name cannot be used with a glob pattern", m.MetricName)
+		default:
+			// $ needs to be escaped, like in exclude_metrics.
+			transforms = append(transforms, otel.UpdateMetricRegexp(strings.ReplaceAll(globToRegex(m.MetricName), "$", "$$"), operations...))
+		}
+	}
+	return []otel.Component{otel.MetricsTransform(transforms...)}, nil
+}
+
+// ValidateGMP rejects operations on the labels that are moved to the resource of Prometheus metrics.
+func (p MetricsProcessorModifyMetrics) ValidateGMP() error {
+	for _, m := range p.Metrics {
+		for _, o := range m.Operations {
+			for _, l := range o.labels() {
+				if slices.Contains(gmpResourceLabels, l) {
+					return fmt.Errorf("modify_metrics cannot change the %q label of Prometheus metrics", l)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// A MetricsProcessorStaticLabels adds labels with fixed values to all the metrics in a pipeline.
+type MetricsProcessorStaticLabels struct {
+	confgenerator.ConfigComponent `yaml:",inline"`
+
+	// Target is "metric" (the default) to add metric labels, or "resource" to add resource attributes.
+	// Only Prometheus metrics keep their resource attributes, which are exported in the target_info metric.
+	Target string `yaml:"target,omitempty" validate:"omitempty,oneof=metric resource"`
+	// The labels that the agent sets itself and the labels that identify the monitored resource of Prometheus metrics are reserved.
+	Labels map[string]StaticLabelValue `yaml:"labels" validate:"required,dive,keys,required,notoneof=instance_id instance_name project_id zone job instance location namespace cluster,endkeys"`
+}
+
+// A StaticLabelValue is the source of the value of a label. Exactly one of its fields must be set.
+type StaticLabelValue struct {
+	Value *string `yaml:"value,omitempty" validate:"required_without_all=EnvVar GCEMetadata GCELabel,excluded_with=EnvVar GCEMetadata GCELabel"`
+	// EnvVar is resolved by the collector when it starts.
+	EnvVar      string `yaml:"env_var,omitempty" validate:"excluded_with=GCEMetadata GCELabel"`
+	GCEMetadata string `yaml:"gce_metadata,omitempty" validate:"excluded_with=GCELabel"`
+	GCELabel    string `yaml:"gce_label,omitempty"`
+}
+
+func (p MetricsProcessorStaticLabels) Type() string {
+	return "static_labels"
+}
+
+// values returns the label values to set, escaped for the collector config.
+// Labels read from GCE are skipped when they are not available.
+func (p MetricsProcessorStaticLabels) values(ctx context.Context) map[string]string {
+	var gce *resourcedetector.GCEResource
+	for _, v := range p.Labels {
+		if v.GCEMetadata == "" && v.GCELabel == "" {
+			continue
+		}
+		resource, err := platform.FromContext(ctx).GetResource()
+		if err != nil {
+			log.Printf("can't get resource metadata: %v", err)
+			break
+		}
+		if r, ok := resource.(resourcedetector.GCEResource); ok {
+			gce = &r
+		} else {
+			log.Printf("ignoring the GCE labels of the static_labels processor outside of GCE: %T", resource)
+		}
+		break
+	}
+	// $ needs to be escaped, like in exclude_metrics.
+	escape := func(s string) string {
+		return strings.ReplaceAll(s, "$", "$$")
+	}
+	values := map[string]string{}
+	for k, v := range p.Labels {
+		switch {
+		case v.Value != nil:
+			values[k] = escape(*v.Value)
+		case v.EnvVar != "":
+			values[k] = fmt.Sprintf("${env:%s}", v.EnvVar)
+		case gce == nil:
+		case v.GCEMetadata != "":
+			if value, ok := gce.Metadata[v.GCEMetadata]; ok {
+				values[k] = escape(value)
+			} else {
+				log.Printf("static_labels: GCE metadata key %q not found", v.GCEMetadata)
+			}
+		case v.GCELabel != "":
+			if value, ok := gce.Label[v.GCELabel]; ok {
+				values[k] = escape(value)
+			} else {
+				log.Printf("static_labels: GCE label %q not found", v.GCELabel)
+			}
+		}
+	}
+	return values
+}
+
+func (p MetricsProcessorStaticLabels) Processors(ctx context.Context) ([]otel.Component, error) {
+	values := p.values(ctx)
+	if p.Target == "resource" {
+		return []otel.Component{otel.ResourceTransform(values, true)}, nil
+	}
+	var operations []map[string]interface{}
+	for _, k := range confgenerator.GetSortedKeys(values) {
+		operations = append(operations, otel.AddLabel(k, values[k]))
+	}
+	if len(operations) == 0 {
+		return nil, nil
+	}
+	return []otel.Component{otel.MetricsTransform(otel.UpdateMetricRegexp(`^.*$$`, operations...))}, nil
+}
+
+// ValidateGMP allows static_labels to be used with Prometheus metrics, since the labels that identify their monitored resource are reserved.
+func (p MetricsProcessorStaticLabels) ValidateGMP() error {
+	return nil
+}
+
+// ValidateNonGMP rejects resource labels, which the Cloud Monitoring exporter drops.
+func (p MetricsProcessorStaticLabels) ValidateNonGMP() error {
+	if p.Target == "resource" {
+		return fmt.Errorf(`static_labels can only set "target: resource" for Prometheus metrics`)
+	}
+	return nil
+}
+
+// gmpResourceLabels are the labels that identify the monitored resource of Prometheus metrics.
+var gmpResourceLabels = []string{"location", "namespace", "cluster"}
+
 func init() {
 	confgenerator.MetricsProcessorTypes.RegisterType(func() confgenerator.MetricsProcessor { return &MetricsProcessorExcludeMetrics{} })
+	confgenerator.MetricsProcessorTypes.RegisterType(func() confgenerator.MetricsProcessor { return &MetricsProcessorFilterMetrics{} })
+	confgenerator.MetricsProcessorTypes.RegisterType(func() confgenerator.MetricsProcessor { return &MetricsProcessorModifyMetrics{} })
+	confgenerator.MetricsProcessorTypes.RegisterType(func() confgenerator.MetricsProcessor { return &MetricsProcessorStaticLabels{} })
 }
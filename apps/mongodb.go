@@ -17,6 +17,7 @@ package apps
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
@@ -46,6 +47,18 @@ func (r MetricsReceiverMongoDB) Type() string {
 	return "mongodb"
 }
 
+// KnownMetrics returns the expected metrics of the mongodb and mongodb3.6 integrations.
+func (MetricsReceiverMongoDB) KnownMetrics() []string {
+	return slices.Concat(thirdPartyAppMetrics["mongodb"], thirdPartyAppMetrics["mongodb3.6"])
+}
+
+func (r MetricsReceiverMongoDB) GetTargetAddress() string {
+	if r.Endpoint == "" {
+		return confgenerator.TargetAddress(defaultMongodbEndpoint)
+	}
+	return confgenerator.TargetAddress(r.Endpoint)
+}
+
 func (r MetricsReceiverMongoDB) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	transport := "tcp"
 	if r.Endpoint == "" {
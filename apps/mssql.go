@@ -34,6 +34,14 @@ func (MetricsReceiverMssql) Type() string {
 	return "mssql"
 }
 
+// KnownMetrics returns the expected metrics of the mssql integration for the receiver version.
+func (r MetricsReceiverMssql) KnownMetrics() []string {
+	if r.ReceiverVersion == "2" {
+		return thirdPartyAppMetrics["mssql"]
+	}
+	return thirdPartyAppMetrics["mssqlv1"]
+}
+
 func (m MetricsReceiverMssql) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	if m.ReceiverVersion == "2" {
 		return []otel.ReceiverPipeline{confgenerator.ConvertGCMOtelExporterToOtlpExporter(otel.ReceiverPipeline{
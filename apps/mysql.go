@@ -17,6 +17,7 @@ package apps
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
@@ -41,6 +42,16 @@ func (r MetricsReceiverMySql) Type() string {
 	return "mysql"
 }
 
+// KnownMetrics returns the expected metrics of the mysql, mysql5.7 and mariadb integrations.
+func (MetricsReceiverMySql) KnownMetrics() []string {
+	return slices.Concat(thirdPartyAppMetrics["mysql"], thirdPartyAppMetrics["mysql5.7"], thirdPartyAppMetrics["mariadb"])
+}
+
+func (r MetricsReceiverMySql) GetTargetAddress() string {
+	// The default endpoint is a Unix socket.
+	return confgenerator.TargetAddress(r.Endpoint)
+}
+
 func (r MetricsReceiverMySql) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	transport := "tcp"
 	if r.Endpoint == "" {
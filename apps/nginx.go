@@ -35,6 +35,18 @@ func (r MetricsReceiverNginx) Type() string {
 	return "nginx"
 }
 
+// KnownMetrics returns the expected metrics of the nginx integration.
+func (MetricsReceiverNginx) KnownMetrics() []string {
+	return thirdPartyAppMetrics["nginx"]
+}
+
+func (r MetricsReceiverNginx) GetTargetAddress() string {
+	if r.StubStatusURL == "" {
+		return confgenerator.TargetAddress(defaultStubStatusURL)
+	}
+	return confgenerator.TargetAddress(r.StubStatusURL)
+}
+
 func (r MetricsReceiverNginx) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	if r.StubStatusURL == "" {
 		r.StubStatusURL = defaultStubStatusURL
@@ -49,6 +49,11 @@ func (r MetricsReceiverOracleDB) Type() string {
 	return "oracledb"
 }
 
+// KnownMetrics returns the expected metrics of the oracledb integration.
+func (MetricsReceiverOracleDB) KnownMetrics() []string {
+	return thirdPartyAppMetrics["oracledb"]
+}
+
 func (r MetricsReceiverOracleDB) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	endpoint := r.Endpoint
 	if r.Endpoint == "" {
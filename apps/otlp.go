@@ -17,7 +17,9 @@ package apps
 import (
 	"context"
 	"fmt"
+	"net"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
@@ -47,6 +49,22 @@ func (r ReceiverOTLP) Type() string {
 	return "otlp"
 }
 
+func (r ReceiverOTLP) GetListenAddress() confgenerator.ListenAddress {
+	endpoint := r.GRPCEndpoint
+	if endpoint == "" {
+		endpoint = defaultGRPCEndpoint
+	}
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return confgenerator.ListenAddress{Protocol: "tcp"}
+	}
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return confgenerator.ListenAddress{Protocol: "tcp", Host: host}
+	}
+	return confgenerator.ListenAddress{Protocol: "tcp", Host: host, Port: uint16(p)}
+}
+
 func (ReceiverOTLP) gmpResourceProcessors(ctx context.Context) []otel.Component {
 	// Keep in sync with logic in confgenerator/prometheus.go
 	stmt := func(target, source, platform string) string {
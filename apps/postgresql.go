@@ -44,6 +44,11 @@ func (r MetricsReceiverPostgresql) Type() string {
 	return "postgresql"
 }
 
+// KnownMetrics returns the expected metrics of the postgresql integration.
+func (MetricsReceiverPostgresql) KnownMetrics() []string {
+	return thirdPartyAppMetrics["postgresql"]
+}
+
 func (r MetricsReceiverPostgresql) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	transport := "tcp"
 	if r.Endpoint == "" {
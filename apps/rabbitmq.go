@@ -123,6 +123,11 @@ func (r MetricsReceiverRabbitmq) Type() string {
 	return "rabbitmq"
 }
 
+// KnownMetrics returns the expected metrics of the rabbitmq integration.
+func (MetricsReceiverRabbitmq) KnownMetrics() []string {
+	return thirdPartyAppMetrics["rabbitmq"]
+}
+
 func (r MetricsReceiverRabbitmq) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	if r.Endpoint == "" {
 		r.Endpoint = defaultRabbitmqTCPEndpoint
@@ -39,6 +39,18 @@ func (r MetricsReceiverRedis) Type() string {
 	return "redis"
 }
 
+// KnownMetrics returns the expected metrics of the redis integration.
+func (MetricsReceiverRedis) KnownMetrics() []string {
+	return thirdPartyAppMetrics["redis"]
+}
+
+func (r MetricsReceiverRedis) GetTargetAddress() string {
+	if r.Address == "" {
+		return confgenerator.TargetAddress(defaultRedisEndpoint)
+	}
+	return confgenerator.TargetAddress(r.Address)
+}
+
 func (r MetricsReceiverRedis) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	if r.Address == "" {
 		r.Address = defaultRedisEndpoint
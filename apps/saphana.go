@@ -137,6 +137,11 @@ func (s MetricsReceiverSapHana) Type() string {
 	return "saphana"
 }
 
+// KnownMetrics returns the expected metrics of the saphana integration.
+func (MetricsReceiverSapHana) KnownMetrics() []string {
+	return thirdPartyAppMetrics["saphana"]
+}
+
 func (s MetricsReceiverSapHana) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	if s.Endpoint == "" {
 		s.Endpoint = defaultSapHanaEndpoint
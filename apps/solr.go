@@ -33,6 +33,11 @@ func (r MetricsReceiverSolr) Type() string {
 	return "solr"
 }
 
+// KnownMetrics returns the expected metrics of the solr integration.
+func (MetricsReceiverSolr) KnownMetrics() []string {
+	return thirdPartyAppMetrics["solr"]
+}
+
 func (r MetricsReceiverSolr) Pipelines(_ context.Context) ([]otel.ReceiverPipeline, error) {
 	targetSystem := "solr"
 
@@ -35,6 +35,11 @@ func (r MetricsReceiverTomcat) Type() string {
 	return "tomcat"
 }
 
+// KnownMetrics returns the expected metrics of the tomcat integration, and of the jvm integration if they are collected.
+func (r MetricsReceiverTomcat) KnownMetrics() []string {
+	return withJVMMetrics(r.MetricsReceiverSharedCollectJVM, thirdPartyAppMetrics["tomcat"])
+}
+
 func (r MetricsReceiverTomcat) Pipelines(_ context.Context) ([]otel.ReceiverPipeline, error) {
 	targetSystem := "tomcat"
 
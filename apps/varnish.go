@@ -32,6 +32,11 @@ func (MetricsReceiverVarnish) Type() string {
 	return "varnish"
 }
 
+// KnownMetrics returns the expected metrics of the varnish integration.
+func (MetricsReceiverVarnish) KnownMetrics() []string {
+	return thirdPartyAppMetrics["varnish"]
+}
+
 func (r MetricsReceiverVarnish) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	return []otel.ReceiverPipeline{confgenerator.ConvertGCMOtelExporterToOtlpExporter(otel.ReceiverPipeline{
 		Receiver: otel.Component{
@@ -74,6 +74,11 @@ func (r MetricsReceiverVault) Type() string {
 	return "vault"
 }
 
+// KnownMetrics returns the expected metrics of the vault integration.
+func (MetricsReceiverVault) KnownMetrics() []string {
+	return thirdPartyAppMetrics["vault"]
+}
+
 func (r MetricsReceiverVault) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	if r.Endpoint == "" {
 		r.Endpoint = defaultVaultEndpoint
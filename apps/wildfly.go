@@ -34,6 +34,11 @@ func (r MetricsReceiverWildfly) Type() string {
 	return "wildfly"
 }
 
+// KnownMetrics returns the expected metrics of the wildfly integration.
+func (MetricsReceiverWildfly) KnownMetrics() []string {
+	return thirdPartyAppMetrics["wildfly"]
+}
+
 func (r MetricsReceiverWildfly) Pipelines(_ context.Context) ([]otel.ReceiverPipeline, error) {
 	targetSystem := "wildfly"
 
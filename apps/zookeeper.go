@@ -39,6 +39,11 @@ func (MetricsReceiverZookeeper) Type() string {
 	return "zookeeper"
 }
 
+// KnownMetrics returns the expected metrics of the zookeeper integration.
+func (MetricsReceiverZookeeper) KnownMetrics() []string {
+	return thirdPartyAppMetrics["zookeeper"]
+}
+
 func (r MetricsReceiverZookeeper) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
 	if r.Endpoint == "" {
 		r.Endpoint = defaultZookeeperEndpoint
@@ -16,9 +16,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	_ "github.com/GoogleCloudPlatform/ops-agent/apps"
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
@@ -28,26 +31,59 @@ import (
 )
 
 var (
-	service      = flag.String("service", "", "service to generate config for")
-	outDir       = flag.String("out", os.Getenv("RUNTIME_DIRECTORY"), "directory to write configuration files to")
-	input        = flag.String("in", "/etc/google-cloud-ops-agent/config.yaml", "path to the user specified agent config")
-	logsDir      = flag.String("logs", "/var/log/google-cloud-ops-agent", "path to store agent logs")
-	stateDir     = flag.String("state", "/var/lib/google-cloud-ops-agent", "path to store agent state like buffers")
-	healthChecks = flag.Bool("healthchecks", false, "run health checks and exit")
+	service            = flag.String("service", "", "service to generate config for, or healthchecks to keep re-running the periodic health checks")
+	outDir             = flag.String("out", os.Getenv("RUNTIME_DIRECTORY"), "directory to write configuration files to")
+	input              = flag.String("in", "/etc/google-cloud-ops-agent/config.yaml", "path to the user specified agent config")
+	logsDir            = flag.String("logs", "/var/log/google-cloud-ops-agent", "path to store agent logs")
+	stateDir           = flag.String("state", "/var/lib/google-cloud-ops-agent", "path to store agent state like buffers")
+	healthChecks       = flag.Bool("healthchecks", false, "run health checks and exit; the exit code is 2 if any health check fails")
+	healthChecksFormat = flag.String("healthchecks_format", "text", "format of the health check results: text or json")
 )
 
-func runHealthChecks() {
-	logger := healthchecks.CreateHealthChecksLogger(*logsDir)
+// errHealthChecksFailed is returned when a health check fails with -healthchecks.
+var errHealthChecksFailed = errors.New("health checks failed")
 
-	defaultLogger := logs.NewSimpleLogger()
+// healthCheckRegistry returns the default health checks and the checks of the receivers of uc.
+func healthCheckRegistry(ctx context.Context, uc *confgenerator.UnifiedConfig) (healthchecks.HealthCheckRegistry, error) {
+	receiverChecks, err := uc.ReceiverHealthChecks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(healthchecks.HealthCheckRegistryFactory(), receiverChecks...), nil
+}
 
-	healthCheckResults := healthchecks.HealthCheckRegistryFactory().RunAllHealthChecks(logger)
+func runHealthChecks(checks healthchecks.HealthCheckRegistry, logger logs.StructuredLogger) ([]healthchecks.HealthCheckResult, error) {
+	healthCheckResults := checks.RunAllHealthChecks(logger)
+	if *healthChecksFormat == "json" {
+		return healthCheckResults, healthchecks.WriteHealthCheckResultsJSON(healthCheckResults, os.Stdout)
+	}
+	defaultLogger := logs.NewSimpleLogger()
 	healthchecks.LogHealthCheckResults(healthCheckResults, defaultLogger)
+	return healthCheckResults, nil
+}
+
+// runPeriodicHealthChecks runs the default periodic health checks, and re-runs
+// them until the engine is stopped. The latest results are written to -out as
+// the health check status self metric.
+func runPeriodicHealthChecks(ctx context.Context) {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	logger := healthchecks.CreateHealthChecksLogger(*logsDir)
+	results := healthchecks.DefaultPeriodicHealthChecks().RunAllHealthChecks(logger)
+	log.Printf("Running health checks every %s", healthchecks.DefaultPeriodicInterval)
+	healthchecks.RunDefaultPeriodicHealthChecks(ctx, logger, results, func(results []healthchecks.HealthCheckResult) error {
+		return self_metrics.WriteHealthCheckStatusOTLPJSON(results, *outDir)
+	})
 }
 
 func main() {
 	flag.Parse()
-	if err := run(); err != nil {
+	if *healthChecksFormat != "text" && *healthChecksFormat != "json" {
+		log.Fatalf("Unsupported -healthchecks_format %q: must be text or json", *healthChecksFormat)
+	}
+	if err := run(); errors.Is(err, errHealthChecksFailed) {
+		os.Exit(2)
+	} else if err != nil {
 		log.Fatalf("The agent config file is not valid. Detailed error: %s", err)
 	}
 }
@@ -68,12 +104,27 @@ func run() error {
 
 	switch *service {
 	case "":
-		runHealthChecks()
+		checks, err := healthCheckRegistry(ctx, uc)
+		if err != nil {
+			return err
+		}
+		logger := healthchecks.CreateHealthChecksLogger(*logsDir)
+		healthCheckResults, err := runHealthChecks(checks, logger)
+		if err != nil {
+			log.Printf("failed to write the health check results: %v", err)
+		}
 		log.Println("Startup checks finished")
 		if *healthChecks {
 			// If healthchecks is set, stop here
+			if healthchecks.AnyFatalHealthCheck(healthCheckResults) {
+				return errHealthChecksFailed
+			}
 			return nil
 		}
+	case "healthchecks":
+		// The health check status is read by the otel service from -out.
+		runPeriodicHealthChecks(ctx)
+		return nil
 	case "otel":
 		// The generated otlp metric json files are used only by the otel service.
 		err = self_metrics.GenerateOpsAgentSelfMetricsOTLPJSON(ctx, *input, *outDir)
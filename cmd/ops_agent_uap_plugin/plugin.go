@@ -29,8 +29,10 @@ import (
 	"buf.build/go/protoyaml"
 	pb "github.com/GoogleCloudPlatform/google-guest-agent/pkg/proto/plugin_comm"
 	_ "github.com/GoogleCloudPlatform/ops-agent/apps"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
 	"github.com/GoogleCloudPlatform/ops-agent/internal/healthchecks"
 	"github.com/GoogleCloudPlatform/ops-agent/internal/logs"
+	"github.com/GoogleCloudPlatform/ops-agent/internal/self_metrics"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -241,11 +243,27 @@ func writeCustomConfigToFile(req *pb.StartRequest, configPath string) error {
 	return nil
 }
 
-func runHealthChecks(healthCheckFileLogger logs.StructuredLogger) {
-	gceHealthChecks := healthchecks.HealthCheckRegistryFactory()
+// runHealthChecks runs the default health checks and the checks of the receivers
+// of the merged config at userConfPath.
+func runHealthChecks(ctx context.Context, userConfPath string, healthCheckFileLogger logs.StructuredLogger) []healthchecks.HealthCheckResult {
+	gceHealthChecks, err := confgenerator.HealthCheckRegistry(ctx, userConfPath)
+	if err != nil {
+		log.Printf("failed to load the receiver health checks, running the default health checks only: %s", err)
+		gceHealthChecks = healthchecks.HealthCheckRegistryFactory()
+	}
 
 	// Log health check results to health-checks.log log file.
-	gceHealthChecks.RunAllHealthChecks(healthCheckFileLogger)
+	healthCheckResults := gceHealthChecks.RunAllHealthChecks(healthCheckFileLogger)
 
 	log.Println("Health checks completed")
+	return healthCheckResults
+}
+
+// runPeriodicHealthChecks re-runs the default periodic health checks until ctx is
+// done, and writes their latest results to otelRuntimeDir as the health check
+// status self metric.
+func runPeriodicHealthChecks(ctx context.Context, healthCheckFileLogger logs.StructuredLogger, startupResults []healthchecks.HealthCheckResult, otelRuntimeDir string) {
+	healthchecks.RunDefaultPeriodicHealthChecks(ctx, healthCheckFileLogger, startupResults, func(results []healthchecks.HealthCheckResult) error {
+		return self_metrics.WriteHealthCheckStatusOTLPJSON(results, otelRuntimeDir)
+	})
 }
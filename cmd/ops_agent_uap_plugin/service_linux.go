@@ -110,7 +110,7 @@ func (ps *OpsAgentPluginServer) Start(ctx context.Context, msg *pb.StartRequest)
 
 	// Trigger Healthchecks.
 	healthCheckFileLogger := healthchecks.CreateHealthChecksLogger(filepath.Join(pluginStateDir, LogsDirectory))
-	runHealthChecks(healthCheckFileLogger)
+	healthCheckResults := runHealthChecks(pContext, OpsAgentConfigLocationLinux, healthCheckFileLogger)
 
 	// Subagent config generation
 	if err := generateSubagentConfigs(pContext, ps.runCommand, pluginInstallDir, pluginStateDir); err != nil {
@@ -120,6 +120,7 @@ func (ps *OpsAgentPluginServer) Start(ctx context.Context, msg *pb.StartRequest)
 
 	// the subagent startups
 	go runSubagents(pContext, ps.cancelAndSetPluginError, pluginInstallDir, pluginStateDir, runSubAgentCommand, ps.runCommand)
+	go runPeriodicHealthChecks(pContext, healthCheckFileLogger, healthCheckResults, path.Join(pluginStateDir, OtelRuntimeDirectory))
 	return &pb.StartResponse{}, nil
 }
 
@@ -121,7 +121,7 @@ func (ps *OpsAgentPluginServer) Start(ctx context.Context, msg *pb.StartRequest)
 
 	// Trigger Healthchecks.
 	healthCheckFileLogger := healthchecks.CreateHealthChecksLogger(filepath.Join(pluginStateDir, LogsDirectory))
-	runHealthChecks(healthCheckFileLogger)
+	healthCheckResults := runHealthChecks(pContext, OpsAgentConfigLocationWindows, healthCheckFileLogger)
 
 	// Create a Windows Job object and stores its handle, to ensure that all child processes are killed when the parent process exits.
 	_, err = createWindowsJobHandle()
@@ -138,6 +138,7 @@ func (ps *OpsAgentPluginServer) Start(ctx context.Context, msg *pb.StartRequest)
 	}
 
 	go runSubagents(pContext, cancelAndSetPluginErr, pluginInstallDir, pluginStateDir, runSubAgentCommand, ps.runCommand)
+	go runPeriodicHealthChecks(pContext, healthCheckFileLogger, healthCheckResults, filepath.Join(pluginStateDir, GeneratedConfigsOutDir, "otel"))
 	return &pb.StartResponse{}, nil
 }
 
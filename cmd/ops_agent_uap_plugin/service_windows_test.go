@@ -22,6 +22,7 @@ import (
 	"errors"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -155,7 +156,7 @@ func Test_runHealthChecks_LogFileNonEmpty(t *testing.T) {
 	defer os.Remove(healthCheckLogFile.Name())
 	mockHealthCheckLogger := &mockHealthCheckLogger{logFile: healthCheckLogFile}
 
-	runHealthChecks(mockHealthCheckLogger)
+	runHealthChecks(context.Background(), filepath.Join(pluginStateDir, "config.yaml"), mockHealthCheckLogger)
 
 	// Check if the log file has content
 	fileInfo, err := os.Stat(healthCheckLogFile.Name())
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
@@ -63,7 +64,10 @@ func main() {
 			}
 			infoLog.Printf("uninstalled services")
 		} else if *healthChecks {
-			healthCheckResults := getHealthCheckResults()
+			healthCheckResults, err := getHealthCheckResults(context.Background(), userConfPath, healthChecksLogger())
+			if err != nil {
+				log.Fatal(err)
+			}
 			healthchecks.LogHealthCheckResults(healthCheckResults, infoLog)
 			infoLog.Println("Health checks finished")
 		} else {
@@ -74,6 +78,9 @@ func main() {
 	}
 }
 
+// userConfPath is the path of the user specified agent config.
+var userConfPath string
+
 var services []struct {
 	name        string
 	displayName string
@@ -98,6 +105,7 @@ func initServices() error {
 	if err != nil {
 		return fmt.Errorf("could not determine binary path: %w", err)
 	}
+	userConfPath = filepath.Join(base, "../config/config.yaml")
 	configOutDir := filepath.Join(os.Getenv("PROGRAMDATA"), dataDirectory, "generated_configs")
 	if err := os.MkdirAll(configOutDir, 0644); err != nil {
 		return err
@@ -127,7 +135,7 @@ func initServices() error {
 			serviceDisplayName,
 			self,
 			[]string{
-				"-in", filepath.Join(base, "../config/config.yaml"),
+				"-in", userConfPath,
 				"-out", configOutDir,
 			},
 		},
@@ -146,7 +154,7 @@ func initServices() error {
 			filepath.Join(base, fmt.Sprintf("%s-wrapper.exe", serviceName)),
 			[]string{
 				"-log_path", filepath.Join(logDirectory, "logging-module.log"),
-				"-config_path", filepath.Join(base, "../config/config.yaml"),
+				"-config_path", userConfPath,
 				filepath.Join(base, "fluent-bit.exe"),
 				"-c", filepath.Join(configOutDir, `fluentbit\fluent_bit_main.conf`),
 				"-R", filepath.Join(configOutDir, `fluentbit\fluent_bit_parser.conf`),
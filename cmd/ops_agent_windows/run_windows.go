@@ -75,7 +75,7 @@ func (s *service) Execute(args []string, r <-chan svc.ChangeRequest, changes cha
 		return false, 2
 	}
 	s.log.Info(EngineEventID, "generated configuration files")
-	s.runHealthChecks()
+	s.runHealthChecks(ctx)
 
 	changes <- svc.Status{State: svc.Running, Accepts: cmdsAccepted}
 	if err := s.startSubagents(); err != nil {
@@ -141,19 +141,39 @@ func (s *service) checkForStandaloneAgents(unified *confgenerator.UnifiedConfig)
 	return nil
 }
 
-func getHealthCheckResults() []healthchecks.HealthCheckResult {
-	logsDir := filepath.Join(os.Getenv("PROGRAMDATA"), dataDirectory, "log")
-	gceHealthChecks := healthchecks.HealthCheckRegistryFactory()
-	logger := healthchecks.CreateHealthChecksLogger(logsDir)
+func healthChecksLogger() logs.StructuredLogger {
+	return healthchecks.CreateHealthChecksLogger(filepath.Join(os.Getenv("PROGRAMDATA"), dataDirectory, "log"))
+}
 
-	return gceHealthChecks.RunAllHealthChecks(logger)
+func getHealthCheckResults(ctx context.Context, userConf string, logger logs.StructuredLogger) ([]healthchecks.HealthCheckResult, error) {
+	gceHealthChecks, err := confgenerator.HealthCheckRegistry(ctx, userConf)
+	if err != nil {
+		return nil, err
+	}
+	return gceHealthChecks.RunAllHealthChecks(logger), nil
 }
 
-func (srv *service) runHealthChecks() {
-	healthCheckResults := getHealthCheckResults()
+func (srv *service) runHealthChecks(ctx context.Context) {
+	healthCheckLogger := healthChecksLogger()
+	healthCheckResults, err := getHealthCheckResults(ctx, srv.userConf, healthCheckLogger)
+	if err != nil {
+		srv.log.Error(EngineEventID, fmt.Sprintf("failed to run health checks: %v", err))
+		return
+	}
 	logger := logs.WindowsServiceLogger{EventID: EngineEventID, Logger: srv.log}
 	healthchecks.LogHealthCheckResults(healthCheckResults, logger)
 	srv.log.Info(EngineEventID, "Startup checks finished")
+	go srv.runPeriodicHealthChecks(ctx, healthCheckLogger, healthCheckResults)
+}
+
+// runPeriodicHealthChecks re-runs the default periodic health checks until ctx is
+// done, and writes their latest results to the otel config directory as the
+// health check status self metric.
+func (srv *service) runPeriodicHealthChecks(ctx context.Context, healthCheckLogger logs.StructuredLogger, startupResults []healthchecks.HealthCheckResult) {
+	otelRuntimeDir := filepath.Join(srv.outDirectory, "otel")
+	healthchecks.RunDefaultPeriodicHealthChecks(ctx, healthCheckLogger, startupResults, func(results []healthchecks.HealthCheckResult) error {
+		return self_metrics.WriteHealthCheckStatusOTLPJSON(results, otelRuntimeDir)
+	})
 }
 
 func (s *service) generateConfigs(ctx context.Context) error {
@@ -418,7 +418,8 @@ func (r AgentSelfMetrics) OpsAgentPipeline(ctx context.Context) otel.ReceiverPip
 	receiverConfig := map[string]any{
 		"include": []string{
 			filepath.Join(r.OtelRuntimeDir, "enabled_receivers_otlp.json"),
-			filepath.Join(r.OtelRuntimeDir, "feature_tracking_otlp.json")},
+			filepath.Join(r.OtelRuntimeDir, "feature_tracking_otlp.json"),
+			filepath.Join(r.OtelRuntimeDir, "health_checks_otlp.json")},
 		"replay_file":   true,
 		"poll_interval": time.Duration(60 * time.Second).String(),
 		"start_at":      "beginning",
@@ -16,6 +16,7 @@ package confgenerator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"path/filepath"
@@ -142,12 +143,20 @@ func (ve validationError) Error() string {
 		return experimentalValidationErrorString(ve)
 	case "ip":
 		return fmt.Sprintf("%q must be an IP address", ve.Field())
+	case "len":
+		return fmt.Sprintf("%q must have a length of %s", ve.Field(), ve.Param())
+	case "max":
+		return fmt.Sprintf("%q must be a maximum of %s", ve.Field(), ve.Param())
 	case "min":
 		return fmt.Sprintf("%q must be a minimum of %s", ve.Field(), ve.Param())
 	case "multipleof_time":
 		return fmt.Sprintf("%q must be a multiple of %s", ve.Field(), ve.Param())
+	case "nefield":
+		return fmt.Sprintf("%q must be different from %q", ve.Field(), ve.Param())
 	case "oneof":
 		return fmt.Sprintf("%q must be one of [%s]", ve.Field(), ve.Param())
+	case "notoneof":
+		return fmt.Sprintf("%q must not be one of [%s]", ve.Field(), ve.Param())
 	case "required":
 		return fmt.Sprintf("%q is a required field", ve.Field())
 	case "required_with":
@@ -156,8 +165,23 @@ func (ve validationError) Error() string {
 		return fmt.Sprintf("%q must not start with %q", ve.Field(), ve.Param())
 	case "startswith":
 		return fmt.Sprintf("%q must start with %q", ve.Field(), ve.Param())
+	case "uppercase":
+		return fmt.Sprintf("%q must be uppercase", ve.Field())
 	case "url":
 		return fmt.Sprintf("%q must be a URL", ve.Field())
+	case "excludesall":
+		return fmt.Sprintf("%q must not contain any of %q", ve.Field(), ve.Param())
+	case "required_without", "required_without_all":
+		return fmt.Sprintf("%q is required if none of [%s] is set", ve.Field(), ve.Param())
+	case "unique":
+		return fmt.Sprintf("%q must not contain duplicate values", ve.Field())
+	case "headerfile":
+		return fmt.Sprintf("%q: %s", ve.Field(), ve.Param())
+	case "redactpattern":
+		return fmt.Sprintf("%q: %v", ve.Field(), validateRedactPattern(ve.Value().(string)))
+	case "excluded_unless":
+		field, value, _ := strings.Cut(ve.Param(), " ")
+		return fmt.Sprintf("%q can only be set when %q is %q", ve.Field(), field, value)
 	case "excluded_with":
 		return fmt.Sprintf("%q cannot be set if one of [%s] is set", ve.Field(), ve.Param())
 	case "filter":
@@ -223,6 +247,10 @@ func newValidator() *validator.Validate {
 		return t >= tmin
 	})
 	v.RegisterStructValidation(validatePrometheusConfig, &promconfig.Config{})
+	v.RegisterStructValidation(validateParseKeyValue, LoggingProcessorParseKeyValue{})
+	v.RegisterStructValidation(validateParseCSV, LoggingProcessorParseCSV{})
+	v.RegisterStructValidation(validateLoggingReceiverSharedTLS, LoggingReceiverSharedTLS{})
+	v.RegisterStructValidation(validateLoggingReceiverSyslog, LoggingReceiverSyslog{})
 	// filter validates that a Cloud Logging filter condition is valid
 	v.RegisterValidation("filter", func(fl validator.FieldLevel) bool {
 		_, err := filter.NewFilter(fl.Field().String())
@@ -287,6 +315,14 @@ func newValidator() *validator.Validate {
 		}
 		return !m2.Equals(*m1)
 	})
+	// redactpattern validates that the value is a regular expression that can be used by the redact_fields processor.
+	v.RegisterValidation("redactpattern", func(fl validator.FieldLevel) bool {
+		return validateRedactPattern(fl.Field().String()) == nil
+	})
+	// notoneof validates that the value is none of the space-separated values of the parameter
+	v.RegisterValidation("notoneof", func(fl validator.FieldLevel) bool {
+		return !slices.Contains(strings.Fields(fl.Param()), fl.Field().String())
+	})
 	// multipleof_time validates that the value duration is a multiple of the parameter
 	v.RegisterValidation("multipleof_time", func(fl validator.FieldLevel) bool {
 		t, ok := fl.Field().Interface().(time.Duration)
@@ -578,6 +614,57 @@ func (m *loggingReceiverMap) GetListenPorts() map[string]uint16 {
 	return receiverPortMap
 }
 
+// LoggingReceiverSharedTLS configures TLS for logging receivers that listen on a port of the host.
+type LoggingReceiverSharedTLS struct {
+	CertFile string `yaml:"cert_file" validate:"required"`
+	KeyFile  string `yaml:"key_file" validate:"required"`
+	// CAFile is used to verify client certificates.
+	CAFile string `yaml:"ca_file" validate:"omitempty"`
+	// ClientAuthRequired rejects clients that do not present a certificate signed by CAFile.
+	ClientAuthRequired bool `yaml:"client_auth_required,omitempty"`
+}
+
+func validateLoggingReceiverSharedTLS(sl validator.StructLevel) {
+	t := sl.Current().Interface().(LoggingReceiverSharedTLS)
+	if t.ClientAuthRequired && t.CAFile == "" {
+		sl.ReportError(reflect.ValueOf(t.CAFile), "ca_file", "CAFile", "required_with", "client_auth_required")
+	}
+}
+
+// FluentBitConfig returns the "tls.*" options of a Fluent Bit input.
+func (t *LoggingReceiverSharedTLS) FluentBitConfig() map[string]string {
+	if t == nil {
+		return nil
+	}
+	config := map[string]string{
+		// https://docs.fluentbit.io/manual/administration/transport-security
+		"tls":          "On",
+		"tls.verify":   "Off",
+		"tls.crt_file": t.CertFile,
+		"tls.key_file": t.KeyFile,
+	}
+	if t.CAFile != "" {
+		config["tls.ca_file"] = t.CAFile
+	}
+	if t.ClientAuthRequired {
+		config["tls.verify"] = "On"
+	}
+	return config
+}
+
+// OTelConfig returns the configtls.ServerConfig of an OpenTelemetry receiver.
+func (t *LoggingReceiverSharedTLS) OTelConfig() map[string]any {
+	config := map[string]any{
+		"cert_file": t.CertFile,
+		"key_file":  t.KeyFile,
+	}
+	if t.ClientAuthRequired {
+		// Setting client_ca_file makes the server require and verify client certificates.
+		config["client_ca_file"] = t.CAFile
+	}
+	return config
+}
+
 type LoggingProcessor interface {
 	Component
 	InternalLoggingProcessor
@@ -638,6 +725,20 @@ type MetricsProcessorMerger interface {
 	MergeMetricsProcessor(p MetricsProcessor) (MetricsReceiver, bool)
 }
 
+// KnownMetricsReceiver is implemented by metrics receivers that know the names of all the metrics they can export.
+type KnownMetricsReceiver interface {
+	// KnownMetrics returns the names of the metrics as they are exported, after any renaming.
+	KnownMetrics() []string
+}
+
+// MetricsSelector is implemented by metrics processors that select metrics by name and never rename them.
+type MetricsSelector interface {
+	// ExcludesMetric returns true if the processor drops every data point of the metric.
+	ExcludesMetric(metric string) bool
+	// UnmatchedPatterns returns the metric patterns of the processor that match none of metrics.
+	UnmatchedPatterns(metrics ...string) []string
+}
+
 type InternalLoggingProcessorMerger interface {
 	// MergeInternalLoggingProcessor attempts to merge p into the current receiver.
 	// It returns the new receiver; and a potentially modified processor or nil.
@@ -860,7 +961,7 @@ type TracesService struct {
 
 func (uc *UnifiedConfig) Validate(ctx context.Context) error {
 	if uc.Logging != nil {
-		if err := uc.ValidateLogging(); err != nil {
+		if err := uc.ValidateLogging(ctx); err != nil {
 			return err
 		}
 	}
@@ -882,7 +983,7 @@ func (uc *UnifiedConfig) Validate(ctx context.Context) error {
 	return nil
 }
 
-func (uc *UnifiedConfig) ValidateLogging() error {
+func (uc *UnifiedConfig) ValidateLogging(ctx context.Context) error {
 	l := uc.Logging
 	subagent := "logging"
 	if len(l.Exporters) > 0 {
@@ -934,6 +1035,39 @@ func (uc *UnifiedConfig) ValidateLogging() error {
 			log.Printf(`The "logging.service.pipelines.%s.exporters" field is deprecated and will be ignored. Please remove it from your configuration.`, id)
 		}
 	}
+	return uc.validateLoggingBackends(ctx)
+}
+
+// LoggingBackendValidator is implemented by logging processors that don't
+// support every logging backend, or only support some of their settings on it.
+type LoggingBackendValidator interface {
+	// ValidateBackend returns an error if the processor can't run on the
+	// logging backend. The error completes a sentence about the processor.
+	ValidateBackend(backend pipelineBackend) error
+}
+
+// errOTelLoggingOnly is returned by ValidateBackend for processors that only
+// run on the OpenTelemetry logging backend.
+var errOTelLoggingOnly = errors.New(`is only supported by the OpenTelemetry logging backend. Please set "logging.service.experimental_otel_logging: true" to use it`)
+
+// validateLoggingBackends checks the processors of every logging pipeline
+// against the backend that the pipeline runs on.
+func (uc *UnifiedConfig) validateLoggingBackends(ctx context.Context) error {
+	pipelines, err := uc.loggingPipelines(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range pipelines {
+		for _, processor := range p.Processors {
+			v, ok := processor.Component.(LoggingBackendValidator)
+			if !ok {
+				continue
+			}
+			if err := v.ValidateBackend(p.Backend); err != nil {
+				return fmt.Errorf(`"logging.processors.%s" of type %q %w`, processor.ID, processor.Component.Type(), err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -1269,7 +1403,10 @@ func (uc *UnifiedConfig) ValidateMetrics(ctx context.Context) error {
 		if len(p.ExporterIDs) > 0 {
 			log.Printf(`The "metrics.service.pipelines.%s.exporters" field is deprecated and will be ignored. Please remove it from your configuration.`, id)
 		}
-		if err := validateAllowCustomProcessors(receivers, p.ReceiverIDs, p.ProcessorIDs); err != nil {
+		if err := validateAllowCustomProcessors(receivers, m.Processors, p.ReceiverIDs, p.ProcessorIDs); err != nil {
+			return err
+		}
+		if err := validateExcludedMetrics(ctx, receivers, m.Processors, id, p.ReceiverIDs, p.ProcessorIDs); err != nil {
 			return err
 		}
 	}
@@ -1333,25 +1470,120 @@ func validateComponentKeys[V any](components map[string]V, refs []string, subage
 }
 
 // CustomProcessorValidator checks if receiver or pipeline allows Ops Agent to have processors
-// Ops Agent cannot have processors if the receiver is of prometheus type, or if it
+// Ops Agent cannot have arbitrary processors if the receiver is of prometheus type, or if it
 // is an OTLP receiver that exports metrics to googlemanagedprometheus
 type CustomProcessorValidator interface {
 	AllowCustomProcessors() bool
 }
 
-// Pipelines that export prometheus metrics are not allowed to have Ops Agent processors
-func validateAllowCustomProcessors(receivers metricsReceiverMap, receiverIDs, processorIDs []string) error {
+// GMPProcessorValidator is implemented by metrics processors that can process metrics
+// exported to Google Managed Prometheus.
+type GMPProcessorValidator interface {
+	// ValidateGMP returns an error if the processor would break the "location", "namespace"
+	// or "cluster" labels that identify the monitored resource of Prometheus metrics.
+	ValidateGMP() error
+}
+
+// NonGMPProcessorValidator is implemented by metrics processors with settings that
+// only apply to metrics exported to Google Managed Prometheus.
+type NonGMPProcessorValidator interface {
+	// ValidateNonGMP returns an error if the processor would have no effect on
+	// metrics exported to Cloud Monitoring.
+	ValidateNonGMP() error
+}
+
+// Pipelines that export prometheus metrics are only allowed to have Ops Agent processors
+// that implement GMPProcessorValidator.
+func validateAllowCustomProcessors(receivers metricsReceiverMap, processors metricsProcessorMap, receiverIDs, processorIDs []string) error {
 	for _, ID := range receiverIDs {
 		receiver, ok := receivers[ID]
 		if !ok {
 			return fmt.Errorf("metric receiver %q is not defined", ID)
 		}
 		if v, ok := receiver.(CustomProcessorValidator); !ok || v.AllowCustomProcessors() {
+			for _, processorID := range processorIDs {
+				v, ok := processors[processorID].(NonGMPProcessorValidator)
+				if !ok {
+					continue
+				}
+				if err := v.ValidateNonGMP(); err != nil {
+					return fmt.Errorf("%s receiver is incompatible with Ops Agent processor %q: %w", ID, processorID, err)
+				}
+			}
 			continue
 		}
 
-		if len(processorIDs) > 0 {
-			return fmt.Errorf("%s receiver is incompatible with Ops Agent processors", ID)
+		for _, processorID := range processorIDs {
+			v, ok := processors[processorID].(GMPProcessorValidator)
+			if !ok {
+				return fmt.Errorf("%s receiver is incompatible with Ops Agent processor %q of type %q", ID, processorID, processors[processorID].Type())
+			}
+			if err := v.ValidateGMP(); err != nil {
+				return fmt.Errorf("%s receiver is incompatible with Ops Agent processor %q: %w", ID, processorID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// isBuiltInMetricsReceiver returns true if receiver is the unmodified built-in metrics receiver id of the platform.
+func isBuiltInMetricsReceiver(ctx context.Context, id string, receiver MetricsReceiver) bool {
+	builtIn, ok := BuiltInConfStructs[platform.FromContext(ctx).Name()]
+	if !ok || builtIn.Metrics == nil {
+		return false
+	}
+	builtInReceiver, ok := builtIn.Metrics.Receivers[id]
+	return ok && reflect.DeepEqual(receiver, builtInReceiver)
+}
+
+// validateExcludedMetrics checks the metrics selected by the processors of a pipeline against the known metrics of its receivers.
+// It returns an error if a receiver would export no metrics, and warns about patterns that match no known metric.
+func validateExcludedMetrics(ctx context.Context, receivers metricsReceiverMap, processors metricsProcessorMap, pipelineID string, receiverIDs, processorIDs []string) error {
+	// Other processors may rename metrics, so only the selectors before the first one of them are checked.
+	var selectorIDs []string
+	for _, id := range processorIDs {
+		if _, ok := processors[id].(MetricsSelector); !ok {
+			break
+		}
+		selectorIDs = append(selectorIDs, id)
+	}
+	if len(selectorIDs) == 0 {
+		return nil
+	}
+	excluded := func(metric string) bool {
+		return slices.ContainsFunc(selectorIDs, func(id string) bool {
+			return processors[id].(MetricsSelector).ExcludesMetric(metric)
+		})
+	}
+	var allKnownMetrics []string
+	allReceiversKnown := true
+	for _, id := range receiverIDs {
+		r, ok := receivers[id].(KnownMetricsReceiver)
+		if !ok {
+			allReceiversKnown = false
+			continue
+		}
+		knownMetrics := r.KnownMetrics()
+		allKnownMetrics = append(allKnownMetrics, knownMetrics...)
+		if len(knownMetrics) == 0 || slices.ContainsFunc(knownMetrics, func(m string) bool { return !excluded(m) }) {
+			continue
+		}
+		if isBuiltInMetricsReceiver(ctx, id, receivers[id]) {
+			// Excluding all the metrics of the built-in receivers is a supported way to disable them.
+			continue
+		}
+		excluderIDs := slices.DeleteFunc(slices.Clone(selectorIDs), func(id string) bool {
+			return !slices.ContainsFunc(knownMetrics, processors[id].(MetricsSelector).ExcludesMetric)
+		})
+		return fmt.Errorf("metrics receiver %q would export no metrics in pipeline %q: all of its metrics are excluded by processors [%s]", id, pipelineID, strings.Join(excluderIDs, ", "))
+	}
+	if !allReceiversKnown {
+		// A pattern may match the metrics of the other receivers.
+		return nil
+	}
+	for _, id := range selectorIDs {
+		for _, pattern := range processors[id].(MetricsSelector).UnmatchedPatterns(allKnownMetrics...) {
+			log.Printf(`Metrics pattern %q of processor %q does not match any metric of the receivers in pipeline %q.`, pattern, id, pipelineID)
 		}
 	}
 	return nil
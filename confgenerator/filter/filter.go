@@ -44,6 +44,9 @@ func NewMember(m string) (*Member, error) {
 	if !ok || r.Operator != "GLOBAL" {
 		return nil, fmt.Errorf("not a field: %#v", out)
 	}
+	if err := r.LHS.ValidateField(); err != nil {
+		return nil, err
+	}
 	return &Member{r.LHS}, nil
 }
 
@@ -82,6 +85,9 @@ func NewFilter(f string) (*Filter, error) {
 		return nil, err
 	}
 	if out, ok := out.(ast.Expression); ok {
+		if err := ast.ValidateFilter(out); err != nil {
+			return nil, err
+		}
 		return &Filter{out}, nil
 	}
 	return nil, fmt.Errorf("not an expression: %+v", out)
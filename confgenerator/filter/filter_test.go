@@ -42,6 +42,19 @@ var validFilters = []string{
 	`"json\u0050ayload".foo = bar`,
 	`jsonPayload.\= = bar`,
 	`jsonPayload."\=" = bar`,
+	`jsonPayload.latency_ms > 500`,
+	`httpRequest.status >= 500`,
+	`jsonPayload.ratio<=0.75`,
+	`jsonPayload.delta < -3`,
+	`jsonPayload.delta < "+.5"`,
+	`jsonPayload.size > 10 AND jsonPayload.size <= 20.5`,
+	`timeout`,
+	`"missing operator"`,
+	`invalid/characters*here`,
+	`"example.com" OR NOT "connection refused"`,
+	`-debug AND jsonPayload.level = error`,
+	`jsonPayload.level = error timeout`,
+	`severity`,
 }
 
 func TestShouldLex(t *testing.T) {
@@ -117,10 +130,13 @@ func TestFilterRoundTrip(t *testing.T) {
 
 func TestInvalidFilters(t *testing.T) {
 	for _, test := range []string{
-		`"missing operator"`,
-		`invalid/characters*here`,
 		`jsonPayload.foo =~ bareword`,
+		`jsonPayload.foo`,
+		`example.com`,
 		`json\u0050ayload.foo = bar`,
+		`jsonPayload.latency_ms > slow`,
+		`jsonPayload.latency_ms < 1e3`,
+		`jsonPayload.latency_ms >= 1.2.3`,
 	} {
 		test := test
 		t.Run(test, func(t *testing.T) {
@@ -161,3 +177,22 @@ func TestValidMembers(t *testing.T) {
 		})
 	}
 }
+
+func TestInvalidMembers(t *testing.T) {
+	for _, test := range []string{
+		`timeout`,
+		`"missing operator"`,
+		`notJsonPayload.foo`,
+		`jsonPayload.foo = bar`,
+	} {
+		test := test
+		t.Run(test, func(t *testing.T) {
+			member, err := NewMember(test)
+			if err != nil {
+				t.Logf("got expected error %v", err)
+				return
+			}
+			t.Errorf("invalid member %q unexpectedly parsed: %v", test, member)
+		})
+	}
+}
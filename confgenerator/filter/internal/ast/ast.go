@@ -15,6 +15,7 @@
 package ast
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -185,6 +186,20 @@ func (m Target) checkValidCharacters() error {
 	return nil
 }
 
+// ValidateField checks that the target refers to a field that is supported by at least one backend.
+func (m Target) ValidateField() error {
+	if err := m.checkValidCharacters(); err != nil {
+		// TODO: Unnecessary, but preserved until we drop fluent-bit.
+		return err
+	}
+	if _, err := m.RecordAccessor(); err != nil {
+		if _, err := m.OTTLAccessor(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // RecordAccessor returns a string that can be used as a key in a FluentBit config
 func (m Target) RecordAccessor() (string, error) {
 	fluentBit, err := m.fluentBitPath()
@@ -350,31 +365,46 @@ func NewRestriction(lhs, operator, rhs Attrib) (*Restriction, error) {
 	}
 	switch lhs := lhs.(type) {
 	case Target:
-		// Eager validation
-		if err := lhs.checkValidCharacters(); err != nil {
-			// TODO: Unnecessary, but preserved until we drop fluent-bit.
-			return nil, err
-		}
-
-		_, err := lhs.RecordAccessor()
-		if err != nil {
-			_, err := lhs.OTTLAccessor()
+		r.LHS = lhs
+		if r.Operator == "GLOBAL" && len(lhs) == 1 {
+			// A bare value is a global restriction, so it doesn't need to be a valid field.
+			// NewMember also parses fields this way and validates LHS itself.
+			rhs, err := UnquoteTextOrString(lhs[0])
 			if err != nil {
 				return nil, err
 			}
+			r.RHS = rhs
+			break
+		}
+		// Eager validation
+		if err := lhs.ValidateField(); err != nil {
+			return nil, err
 		}
-		r.LHS = lhs
 	default:
 		return nil, fmt.Errorf("unknown lhs: %v", lhs)
 	}
 	switch rhs := rhs.(type) {
 	case nil:
 	case Target:
+		// Perform the appropriate unquoting depending on what operator is being used.
+		switch r.Operator {
+		case "<", "<=", ">", ">=":
+			// Decimal numbers like 1.5 are lexed as a dotted member, so put them back together.
+			parts, err := rhs.Unquote()
+			if err != nil {
+				return nil, err
+			}
+			rhs := strings.Join(parts, ".")
+			if !numericValue.MatchString(rhs) {
+				return nil, fmt.Errorf("operator %s requires a numeric value, got %q", r.Operator, rhs)
+			}
+			r.RHS = rhs
+			return &r, nil
+		}
 		// BNF parses values as Target, even if they are singular
 		if len(rhs) != 1 {
 			return nil, fmt.Errorf("unexpected rhs: %v", rhs)
 		}
-		// Perform the appropriate unquoting depending on what operator is being used.
 		switch r.Operator {
 		case "=~", "!~":
 			rhs := rhs[0]
@@ -411,6 +441,43 @@ func (r Restriction) String() string {
 	return fmt.Sprintf(`%s %s %s`, r.LHS, r.Operator, escapeFilterString(r.RHS))
 }
 
+// Numeric comparisons only accept numbers written in decimal notation. The same
+// rule is used to decide whether a string field holds a number, so that both
+// backends coerce values identically. luaNumericValue is the Lua pattern
+// equivalent of numericValue (Lua patterns have no alternation).
+var (
+	numericValue    = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)$`)
+	luaNumericValue = []string{`^[+-]?%d+%.?%d*$`, `^[+-]?%.%d+$`}
+)
+
+// jsonStringValuePattern returns a case-insensitive regex that matches the JSON
+// encoding of a map or list if any string value in it (but not a key) contains
+// the substring. OTTL's IsMatch encodes maps and lists as JSON before matching,
+// and JSON escapes each character independently, so the substring can be
+// escaped the same way and searched for inside a single JSON string.
+func jsonStringValuePattern(substring string) string {
+	b, err := json.Marshal(substring)
+	if err != nil {
+		// Marshaling a string cannot fail.
+		panic(err)
+	}
+	escaped := string(b[1 : len(b)-1])
+	// Any sequence of characters that does not leave the current JSON string.
+	const stringChars = `(?:[^"\\]|\\.)*`
+	// Keys are always followed by ':'; values by ',', '}', or ']'.
+	return fmt.Sprintf(`(?i)"%s%s%s"[,}\]]`, stringChars, regexp.QuoteMeta(escaped), stringChars)
+}
+
+// numericRHS returns the parsed RHS of a numeric comparison.
+func (r Restriction) numericRHS() float64 {
+	f, err := strconv.ParseFloat(r.RHS, 64)
+	if err != nil {
+		// NewRestriction only accepts values that match numericValue.
+		panic(fmt.Errorf("RHS %q is not a number: %w", r.RHS, err))
+	}
+	return f
+}
+
 func modify(tag, key string) fluentbit.Component {
 	return fluentbit.Component{
 		Kind: "FILTER",
@@ -443,13 +510,35 @@ func (r Restriction) FluentConfig(tag, key string) ([]fluentbit.Component, strin
 	rhsQuoted := LuaQuote(r.RHS)
 	rhsRegex := escapeWhitespaceFluentBit(r.RHS)
 
-	// TODO: Add support for numeric comparisons
-
 	var expr string
 
 	switch r.Operator {
-	case "GLOBAL", "<", "<=", ">", ">=":
-		panic(fmt.Errorf("unimplemented operator: %s", r.Operator))
+	case "GLOBAL":
+		// any string value anywhere in the record contains the value, case insensitive
+		return nil, fmt.Sprintf(`(function(needle)
+local function search(t)
+for k, v in pairs(t) do
+if type(k) ~= "string" or string.sub(k, 1, 8) ~= "__match_" then
+if type(v) == "string" and string.find(string.lower(v), needle, 1, true) ~= nil then
+return true
+end
+if type(v) == "table" and search(v) then
+return true
+end
+end
+end
+return false
+end
+return search(record)
+end)(string.lower(%s))`, rhsQuoted)
+	case "<", "<=", ">", ">=":
+		// numeric comparison; only numbers and strings containing a decimal number are comparable
+		toNumber := fmt.Sprintf(
+			`(type(v) == "number" and v) or (type(v) == "string" and (string.match(v, %s) or string.match(v, %s)) and tonumber(v)) or nil`,
+			LuaQuote(luaNumericValue[0]), LuaQuote(luaNumericValue[1]),
+		)
+		rhsNumber := strconv.FormatFloat(r.numericRHS(), 'f', -1, 64)
+		expr = fmt.Sprintf(`(function(n) return n ~= nil and n %s %s end)(%s)`, r.Operator, rhsNumber, toNumber)
 	case ":":
 		// substring match, case insensitive
 		expr = fmt.Sprintf(`(string.find(string.lower(tostring(v)), string.lower(%s), 1, false) ~= nil)`, rhsQuoted)
@@ -489,13 +578,31 @@ func (r Restriction) FluentConfig(tag, key string) ([]fluentbit.Component, strin
 func (r Restriction) OTTLExpression() (ottl.Value, error) {
 	lhs, _ := r.LHS.OTTLAccessor()
 
-	// TODO: Add support for numeric comparisons
-
 	var expr ottl.Value
 
 	switch r.Operator {
-	case "GLOBAL", "<", "<=", ">", ">=":
-		return nil, fmt.Errorf("unimplemented operator: %s", r.Operator)
+	case "GLOBAL":
+		// any string value in the body, attributes, or severity contains the value, case insensitive
+		// N.B. Unlike fluent-bit, the agent-populated labels are already present in attributes at this point.
+		body := ottl.LValue{"body"}
+		severity := ottl.LValue{"severity_text"}
+		substring := fmt.Sprintf(`(?i)%s`, regexp.QuoteMeta(r.RHS))
+		return ottl.Or(
+			ottl.And(body.IsString(), ottl.IsMatch(body, substring)),
+			ottl.And(body.IsMap(), ottl.IsMatch(body, jsonStringValuePattern(r.RHS))),
+			ottl.IsMatch(ottl.LValue{"attributes"}, jsonStringValuePattern(r.RHS)),
+			ottl.And(severity.IsString(), ottl.IsMatch(severity, substring)),
+		), nil
+	case "<", "<=", ">", ">=":
+		// numeric comparison; only numbers and strings containing a decimal number are comparable
+		expr = ottl.And(
+			ottl.Or(
+				lhs.IsInt(),
+				lhs.IsDouble(),
+				ottl.And(lhs.IsString(), ottl.IsMatch(lhs, numericValue.String())),
+			),
+			ottl.Compare(ottl.ToFloat(lhs), r.Operator, ottl.FloatLiteral(r.numericRHS())),
+		)
 	case ":":
 		// substring match, case insensitive
 		expr = ottl.IsMatch(lhs, fmt.Sprintf(`(?i)%s`, regexp.QuoteMeta(r.RHS)))
@@ -537,6 +644,26 @@ func (r Restriction) HasRubyRegex() bool {
 	return false
 }
 
+// ValidateFilter checks restrictions that are accepted by the grammar but cannot be used in a filter.
+func ValidateFilter(e Expression) error {
+	switch e := e.(type) {
+	case Restriction:
+		if e.Operator == "GLOBAL" && len(e.LHS) != 1 {
+			// Dotted bare values are parsed as fields so that NewMember can use them.
+			return fmt.Errorf("field %s is missing an operator; quote the value to search for it in all fields", e.LHS)
+		}
+	case Conjunction:
+		return exprSlice(e).validate()
+	case Disjunction:
+		return exprSlice(e).validate()
+	case *Negation:
+		return ValidateFilter(e.Expression)
+	case Negation:
+		return ValidateFilter(e.Expression)
+	}
+	return nil
+}
+
 type Expression interface {
 	// Simplify returns a logically equivalent Expression.
 	Simplify() Expression
@@ -618,6 +745,14 @@ func (s exprSlice) OTTLExpression(operator func(...ottl.Value) ottl.Value) (ottl
 	return operator(values...), err
 }
 
+func (s exprSlice) validate() error {
+	var err error
+	for _, e := range s {
+		multierr.AppendInto(&err, ValidateFilter(e))
+	}
+	return err
+}
+
 func (s exprSlice) HasRubyRegex() bool {
 	for _, e := range s {
 		if e.HasRubyRegex() {
@@ -15,6 +15,9 @@
 package ast
 
 import (
+	"encoding/json"
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -155,3 +158,32 @@ func TestInvalidFluentBitPath(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONStringValuePattern(t *testing.T) {
+	for _, test := range []struct {
+		substring string
+		in        any
+		want      bool
+	}{
+		{"timeout", map[string]any{"message": "Request TIMEOUT after 3s"}, true},
+		{"timeout", map[string]any{"timeout": "3s"}, false},
+		{"timeout", map[string]any{"nested": map[string]any{"errors": []any{"ok", "read timeout"}}}, true},
+		{"timeout", map[string]any{"a": "time", "b": "out"}, false},
+		{"true", map[string]any{"flag": true}, false},
+		{`say "hi"`, map[string]any{"quote": `they say "hi" <b>`}, true},
+		{`<b>`, map[string]any{"quote": `they say "hi" <b>`}, true},
+		{`a":"b`, map[string]any{"a": "b"}, false},
+	} {
+		test := test
+		t.Run(fmt.Sprintf("%s in %v", test.substring, test.in), func(t *testing.T) {
+			b, err := json.Marshal(test.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			re := regexp.MustCompile(jsonStringValuePattern(test.substring))
+			if got := re.Match(b); got != test.want {
+				t.Errorf("%s matching %s: got %v, want %v", re, b, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,381 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confgenerator
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/filter"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/fluentbit"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel/ottl"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	parseCSVDefaultDelimiter = ","
+	parseCSVDefaultQuoteChar = `"`
+	// parseCSVPlaceholder temporarily replaces double quotes when OTTL's ParseCSV is used with a different quote character.
+	parseCSVPlaceholder = "\x00"
+)
+
+// parseCSVLua parses a delimited row and re-encodes it as a JSON object keyed by column.
+// The quoting rules mirror Go's encoding/csv, which is used by OTTL's ParseCSV function:
+//   - a field that starts with the quote character extends to the matching closing quote,
+//     and two consecutive quote characters inside it stand for one;
+//   - a closing quote must be followed by the delimiter or the end of the row;
+//   - an unquoted field must not contain the quote character.
+//
+// Rows that break these rules or do not have one field per column are left unparsed.
+// Rows whose fields all equal their column names, such as the header line of a file, are dropped.
+const parseCSVLua = `
+local function parse_row(s)
+  local fields = {}
+  local i = 1
+  while true do
+    if s:sub(i, i) == quote then
+      local buf = {}
+      i = i + 1
+      while true do
+        local j = string.find(s, quote, i, true)
+        if j == nil then
+          return nil
+        end
+        table.insert(buf, s:sub(i, j - 1))
+        i = j + 1
+        if s:sub(i, i) ~= quote then
+          break
+        end
+        table.insert(buf, quote)
+        i = i + 1
+      end
+      table.insert(fields, table.concat(buf))
+      if i > #s then
+        return fields
+      end
+      if s:sub(i, i + #delimiter - 1) ~= delimiter then
+        return nil
+      end
+      i = i + #delimiter
+    else
+      local j = string.find(s, delimiter, i, true)
+      local f = s:sub(i, (j or #s + 1) - 1)
+      if string.find(f, quote, 1, true) then
+        return nil
+      end
+      table.insert(fields, f)
+      if j == nil then
+        return fields
+      end
+      i = j + #delimiter
+    end
+  end
+end
+
+function parse_csv(tag, timestamp, record)
+  local source = record[field]
+  if type(source) ~= "string" or source == "" then
+    return 0, 0, 0
+  end
+  local fields = parse_row(source)
+  if fields == nil or #fields ~= #columns then
+    return 0, 0, 0
+  end
+  local values = {}
+  local header = true
+  for i, k in ipairs(columns) do
+    values[k] = fields[i]
+    if fields[i] ~= k then
+      header = false
+    end
+  end
+  if header then
+    return -1, 0, 0
+  end
+  record[field] = json_object(columns, values)
+  return 2, 0, record
+end
+`
+
+// A LoggingProcessorParseCSV parses the specified field as a row of delimited values, such as CSV or TSV.
+type LoggingProcessorParseCSV struct {
+	ConfigComponent `yaml:",inline"`
+	ParserShared    `yaml:",inline"`
+	Field           string `yaml:"field,omitempty" validate:"omitempty,fieldlegacy"`
+	// Columns names the fields of each row, in order.
+	Columns []string `yaml:"columns,omitempty" validate:"required_without=HeaderFile,excluded_with=HeaderFile,omitempty,unique,dive,required"`
+	// HeaderFile is a file whose first line contains the column names, usually the log file itself.
+	// It is only read when the configuration is generated, when the agent starts, so the agent must be
+	// restarted to pick up a new header. The header cannot be read from the log records at runtime:
+	// both backends usually start tailing a file after its first line, and OTTL cannot carry state from
+	// one record to the next.
+	HeaderFile string `yaml:"header_file,omitempty"`
+	// Delimiter separates fields. Defaults to ",".
+	Delimiter string `yaml:"delimiter,omitempty" validate:"omitempty,len=1"`
+	// QuoteChar encloses fields that contain the delimiter. Defaults to a double quote.
+	QuoteChar string `yaml:"quote_char,omitempty" validate:"omitempty,len=1"`
+	// Types converts the named columns, which are otherwise strings.
+	Types map[string]string `yaml:"types,omitempty" validate:"dive,oneof=string integer bool float hex"`
+}
+
+func (p LoggingProcessorParseCSV) Type() string {
+	return "parse_csv"
+}
+
+func (p LoggingProcessorParseCSV) delimiter() string {
+	if p.Delimiter == "" {
+		return parseCSVDefaultDelimiter
+	}
+	return p.Delimiter
+}
+
+func (p LoggingProcessorParseCSV) quoteChar() string {
+	if p.QuoteChar == "" {
+		return parseCSVDefaultQuoteChar
+	}
+	return p.QuoteChar
+}
+
+// parseRow is the Go equivalent of parse_row in parseCSVLua.
+func (p LoggingProcessorParseCSV) parseRow(s string) ([]string, error) {
+	delimiter, quote := p.delimiter(), p.quoteChar()
+	var fields []string
+	for {
+		if !strings.HasPrefix(s, quote) {
+			f, rest, found := strings.Cut(s, delimiter)
+			if strings.Contains(f, quote) {
+				return nil, fmt.Errorf("unexpected %s in unquoted field %q", quote, f)
+			}
+			fields = append(fields, f)
+			if !found {
+				return fields, nil
+			}
+			s = rest
+			continue
+		}
+		var buf strings.Builder
+		s = s[len(quote):]
+		for {
+			f, rest, found := strings.Cut(s, quote)
+			if !found {
+				return nil, fmt.Errorf("missing closing %s", quote)
+			}
+			buf.WriteString(f)
+			s = rest
+			if !strings.HasPrefix(s, quote) {
+				break
+			}
+			buf.WriteString(quote)
+			s = s[len(quote):]
+		}
+		fields = append(fields, buf.String())
+		if s == "" {
+			return fields, nil
+		}
+		if !strings.HasPrefix(s, delimiter) {
+			return nil, fmt.Errorf("unexpected text %q after closing %s", s, quote)
+		}
+		s = s[len(delimiter):]
+	}
+}
+
+// columns returns the configured column names, reading them from HeaderFile if necessary.
+func (p LoggingProcessorParseCSV) columns() ([]string, error) {
+	if p.HeaderFile == "" {
+		return p.Columns, nil
+	}
+	f, err := os.Open(p.HeaderFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("file is empty")
+	}
+	columns, err := p.parseRow(strings.TrimSuffix(scanner.Text(), "\r"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the first line: %w", err)
+	}
+	return columns, nil
+}
+
+// parseCSVReservedChars cannot be used as a delimiter or quote character.
+// Rows never contain line breaks, and "$" would need escaping in the OpenTelemetry configuration.
+const parseCSVReservedChars = "\r\n$"
+
+func validateParseCSV(sl validator.StructLevel) {
+	p := sl.Current().Interface().(LoggingProcessorParseCSV)
+	if strings.ContainsAny(p.Delimiter, parseCSVReservedChars) {
+		sl.ReportError(reflect.ValueOf(p.Delimiter), "delimiter", "Delimiter", "excludesall", parseCSVReservedChars)
+		return
+	}
+	if strings.ContainsAny(p.QuoteChar, parseCSVReservedChars) {
+		sl.ReportError(reflect.ValueOf(p.QuoteChar), "quote_char", "QuoteChar", "excludesall", parseCSVReservedChars)
+		return
+	}
+	if p.delimiter() == p.quoteChar() {
+		sl.ReportError(reflect.ValueOf(p.QuoteChar), "quote_char", "QuoteChar", "nefield", "delimiter")
+		return
+	}
+	for _, c := range p.Columns {
+		if strings.Contains(c, p.delimiter()) {
+			sl.ReportError(reflect.ValueOf(p.Columns), "columns", "Columns", "excludesall", p.delimiter())
+			return
+		}
+	}
+	if p.HeaderFile == "" || len(p.Columns) > 0 {
+		return
+	}
+	columns, err := p.columns()
+	if err == nil {
+		seen := map[string]bool{}
+		for _, c := range columns {
+			if c == "" || seen[c] || strings.Contains(c, p.delimiter()) {
+				err = fmt.Errorf("invalid column name %q in the first line", c)
+				break
+			}
+			seen[c] = true
+		}
+	}
+	if err != nil {
+		sl.ReportError(reflect.ValueOf(p.HeaderFile), "header_file", "HeaderFile", "headerfile", err.Error())
+	}
+}
+
+func (p LoggingProcessorParseCSV) Components(ctx context.Context, tag, uid string) []fluentbit.Component {
+	columns, err := p.columns()
+	if err != nil {
+		// Shouldn't be possible if validation works.
+		panic(err)
+	}
+	field := p.Field
+	if field == "" {
+		field = "message"
+	}
+	var quotedColumns []string
+	for _, c := range columns {
+		quotedColumns = append(quotedColumns, filter.LuaQuote(c))
+	}
+	var types []string
+	for _, k := range GetSortedKeys(p.Types) {
+		types = append(types, fmt.Sprintf("[%s] = %s", filter.LuaQuote(k), filter.LuaQuote(p.Types[k])))
+	}
+	lua := fmt.Sprintf(`
+local field = %s
+local delimiter = %s
+local quote = %s
+local columns = {%s}
+local types = {%s}
+`, filter.LuaQuote(field), filter.LuaQuote(p.delimiter()), filter.LuaQuote(p.quoteChar()), strings.Join(quotedColumns, ", "), strings.Join(types, ", "))
+
+	parser, parserName := fluentbit.ParserComponentBase(p.TimeFormat, p.TimeKey, nil, tag, uid)
+	parser.Config["Format"] = "json"
+
+	components := fluentbit.LuaFilterComponents(tag, "parse_csv", lua+parserLuaJSON+parseCSVLua)
+	components = append(components, fluentbit.ParserFilterComponents(tag, p.Field, []string{parserName}, false)...)
+	components = append(components, parser)
+	return components
+}
+
+func (p LoggingProcessorParseCSV) Processors(ctx context.Context) ([]otel.Component, error) {
+	columns, err := p.columns()
+	if err != nil {
+		return nil, err
+	}
+	from := p.Field
+	if from == "" {
+		from = "jsonPayload.message"
+	}
+	m, err := filter.NewMemberLegacy(from)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAccessor, err := m.OTTLAccessor()
+	if err != nil {
+		return nil, err
+	}
+
+	delimiter, quote := p.delimiter(), p.quoteChar()
+	row := ottl.LValue{"cache", "__csv_row"}
+	cachedCSV := ottl.LValue{"cache", "__parsed_csv"}
+	statements := ottl.NewStatements(
+		row.SetIf(fromAccessor, ottl.And(fromAccessor.IsPresent(), fromAccessor.IsString())),
+	)
+	if quote != parseCSVDefaultQuoteChar {
+		// ParseCSV only supports double quotes, so swap them with the configured quote character before parsing, and back afterwards.
+		present := row.IsPresent()
+		statements = statements.Append(
+			row.ReplacePatternIf(`"`, ottl.StringLiteral(parseCSVPlaceholder), "", "", present),
+			row.ReplacePatternIf(regexp.QuoteMeta(quote), ottl.StringLiteral(`"`), "", "", present),
+		)
+	}
+	statements = statements.Append(
+		cachedCSV.SetIf(ottl.ParseCSV(row, strings.Join(columns, delimiter), delimiter, "strict"), row.IsPresent()),
+	)
+	if quote != parseCSVDefaultQuoteChar {
+		present := cachedCSV.IsPresent()
+		statements = statements.Append(
+			cachedCSV.ReplaceAllPatternsIf("value", `"`, ottl.StringLiteral(quote), "", "", present),
+			cachedCSV.ReplaceAllPatternsIf("value", parseCSVPlaceholder, ottl.StringLiteral(`"`), "", "", present),
+		)
+	}
+	statements = statements.Append(
+		fromAccessor.DeleteIf(cachedCSV.IsPresent()),
+		ottl.LValue{"body"}.MergeMapsIf(cachedCSV, "upsert", cachedCSV.IsPresent()),
+		cachedCSV.Delete(),
+		row.Delete(),
+	)
+
+	// Drop header rows before any of the fields are converted.
+	var header []ottl.Value
+	for _, c := range columns {
+		header = append(header, ottl.Equals(ottl.LValue{"body", c}, ottl.StringLiteral(c)))
+	}
+
+	shared := p.ParserShared
+	shared.Types = p.Types
+	conversions, err := shared.TimestampStatements()
+	if err != nil {
+		return nil, err
+	}
+	ts, err := shared.TypesStatements()
+	if err != nil {
+		return nil, err
+	}
+	conversions = conversions.Append(ts)
+	conversions = conversions.Append(shared.FluentBitSpecialFieldsStatements(ctx))
+
+	return []otel.Component{
+		otel.Transform("log", "log", statements),
+		otel.Filter("logs", "log_record", []ottl.Value{ottl.And(header...)}),
+		otel.Transform("log", "log", conversions),
+	}, nil
+}
+
+func init() {
+	LoggingProcessorTypes.RegisterType(func() LoggingProcessor { return &LoggingProcessorParseCSV{} })
+}
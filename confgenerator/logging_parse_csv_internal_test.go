@@ -0,0 +1,78 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confgenerator
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestParseCSVRowMatchesEncodingCSV checks that parseRow (and therefore the Lua implementation it mirrors)
+// splits rows like encoding/csv, which OTTL's ParseCSV uses.
+func TestParseCSVRowMatchesEncodingCSV(t *testing.T) {
+	for _, row := range []string{
+		`a,b,c`,
+		`a,,c,`,
+		`"a,b",c`,
+		`"say ""hi""",x`,
+		`"",""`,
+		`a"b,c`,
+		`"a"b,c`,
+		`"unterminated,c`,
+		`a;b,c`,
+	} {
+		t.Run(row, func(t *testing.T) {
+			r := csv.NewReader(strings.NewReader(row))
+			r.FieldsPerRecord = -1
+			want, wantErr := r.Read()
+			got, err := LoggingProcessorParseCSV{}.parseRow(row)
+			if (err != nil) != (wantErr != nil) {
+				t.Fatalf("parseRow(%q) returned error %v, encoding/csv returned error %v", row, err, wantErr)
+			}
+			if err == nil && !slices.Equal(got, want) {
+				t.Errorf("parseRow(%q) = %q, want %q", row, got, want)
+			}
+		})
+	}
+}
+
+func TestParseCSVRowCustomQuote(t *testing.T) {
+	p := LoggingProcessorParseCSV{Delimiter: "\t", QuoteChar: "'"}
+	got, err := p.parseRow("'it''s'\t\"quoted\"\tplain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"it's", `"quoted"`, "plain"}; !slices.Equal(got, want) {
+		t.Errorf("parseRow() = %q, want %q", got, want)
+	}
+}
+
+func TestParseCSVHeaderFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "header.csv")
+	if err := os.WriteFile(path, []byte("time,\"user name\",bytes\r\n2024-01-01,alice,3\r\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoggingProcessorParseCSV{HeaderFile: path}.columns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"time", "user name", "bytes"}; !slices.Equal(got, want) {
+		t.Errorf("columns() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confgenerator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/filter"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/fluentbit"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel/ottl"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	parseKeyValueDefaultFieldDelimiter = "="
+	parseKeyValueDefaultPairDelimiter  = " "
+	// ParseKeyValue always treats double and single quotes as quote characters; when a different quote character is
+	// configured, they are temporarily replaced with these placeholders.
+	parseKeyValueDoubleQuotePlaceholder = "\x00"
+	parseKeyValueSingleQuotePlaceholder = "\x01"
+)
+
+// parserLuaJSON defines Lua functions for parsers that hand a JSON object to Fluent Bit's JSON parser.
+// That parser does not support Types, so values are converted while they are encoded.
+// It expects a `types` table mapping field names to ParserShared types.
+const parserLuaJSON = `
+local json_escapes = {
+  ['"'] = '\\"', ['\\'] = '\\\\', ['\b'] = '\\b', ['\f'] = '\\f',
+  ['\n'] = '\\n', ['\r'] = '\\r', ['\t'] = '\\t',
+}
+
+local function json_string(s)
+  return '"' .. s:gsub('[%c"\\]', function(c)
+    return json_escapes[c] or string.format("\\u%04x", c:byte())
+  end) .. '"'
+end
+
+-- typecast returns v converted to type t as a JSON literal, or nil if v cannot be converted.
+local function typecast(v, t)
+  if t == "integer" then
+    if v:match("^[-+]?%d+$") then
+      return string.format("%d", tonumber(v))
+    end
+  elseif t == "float" then
+    local n = tonumber(v)
+    if n ~= nil and n == n and n ~= math.huge and n ~= -math.huge then
+      local s = string.format("%.17g", n)
+      if not s:match("[.e]") then
+        s = s .. ".0"
+      end
+      return s
+    end
+  elseif t == "hex" then
+    local sign, digits = v:match("^([-+]?)(%x+)$")
+    if digits ~= nil then
+      local n = tonumber(digits, 16)
+      if sign == "-" then
+        n = -n
+      end
+      return string.format("%d", n)
+    end
+  elseif t == "bool" then
+    if v == "true" or v == "false" then
+      return v
+    end
+  elseif t == "string" then
+    return json_string(v)
+  end
+  return nil
+end
+
+-- json_object encodes values[k] for each k in keys as a JSON object, converting them according to types.
+local function json_object(keys, values)
+  local out = {}
+  for _, k in ipairs(keys) do
+    local v = values[k]
+    if types[k] ~= nil then
+      v = typecast(v, types[k]) or json_string(v)
+    else
+      v = json_string(v)
+    end
+    table.insert(out, json_string(k) .. ":" .. v)
+  end
+  return "{" .. table.concat(out, ",") .. "}"
+end
+`
+
+// parseKeyValueLua splits a string into key/value pairs and re-encodes them as a JSON object,
+// which is then handed to Fluent Bit's JSON parser so that time_key and the special fields are
+// handled exactly as in parse_json.
+// The splitting rules mirror OTTL's ParseKeyValue function:
+//   - pairs are separated by pair_delimiter; empty pairs are ignored;
+//   - quote characters (single or double quotes by default) group text containing delimiters, and are removed;
+//   - each pair is split at the first field_delimiter, and the key and value are trimmed;
+//   - if a quote is not closed or a pair has no field_delimiter, the field is left unparsed;
+//   - if a key occurs more than once, the last value wins.
+const parseKeyValueLua = `
+local function split(s, delimiter)
+  local result = {}
+  local current = {}
+  local quote = nil
+  local i = 1
+  while i <= #s do
+    local c = s:sub(i, i)
+    if quote == nil and s:sub(i, i + #delimiter - 1) == delimiter then
+      if #current > 0 then
+        table.insert(result, table.concat(current))
+        current = {}
+      end
+      i = i + #delimiter
+    else
+      if quote == nil and quotes[c] then
+        quote = c
+      elseif c == quote then
+        quote = nil
+      else
+        table.insert(current, c)
+      end
+      i = i + 1
+    end
+  end
+  if quote ~= nil then
+    return nil
+  end
+  if #current > 0 then
+    table.insert(result, table.concat(current))
+  end
+  return result
+end
+
+local function trim(s)
+  return s:match("^%s*(.-)%s*$")
+end
+
+function parse_key_value(tag, timestamp, record)
+  local source = record[field]
+  if type(source) ~= "string" or source == "" then
+    return 0, 0, 0
+  end
+  local kvs = split(source, pair_delimiter)
+  if kvs == nil then
+    return 0, 0, 0
+  end
+  local keys = {}
+  local values = {}
+  for _, pair in ipairs(kvs) do
+    local i = string.find(pair, field_delimiter, 1, true)
+    if i == nil then
+      return 0, 0, 0
+    end
+    local k = trim(pair:sub(1, i - 1))
+    if values[k] == nil then
+      table.insert(keys, k)
+    end
+    values[k] = trim(pair:sub(i + #field_delimiter))
+  end
+  record[field] = json_object(keys, values)
+  return 2, 0, record
+end
+`
+
+// A LoggingProcessorParseKeyValue parses the specified field as a list of key/value pairs, such as logfmt.
+type LoggingProcessorParseKeyValue struct {
+	ConfigComponent `yaml:",inline"`
+	ParserShared    `yaml:",inline"`
+	Field           string `yaml:"field,omitempty" validate:"omitempty,fieldlegacy"`
+	// FieldDelimiter separates a key from its value. Defaults to "=".
+	FieldDelimiter string `yaml:"field_delimiter,omitempty"`
+	// PairDelimiter separates key/value pairs from each other. Defaults to " ".
+	PairDelimiter string `yaml:"pair_delimiter,omitempty"`
+	// QuoteChar encloses keys and values that contain a delimiter. Defaults to both single and double quotes.
+	QuoteChar string `yaml:"quote_char,omitempty" validate:"omitempty,len=1"`
+	// Types converts the named fields, which are otherwise strings.
+	Types map[string]string `yaml:"types,omitempty" validate:"dive,oneof=string integer bool float hex"`
+}
+
+func (p LoggingProcessorParseKeyValue) Type() string {
+	return "parse_key_value"
+}
+
+func (p LoggingProcessorParseKeyValue) delimiters() (string, string) {
+	fieldDelimiter, pairDelimiter := p.FieldDelimiter, p.PairDelimiter
+	if fieldDelimiter == "" {
+		fieldDelimiter = parseKeyValueDefaultFieldDelimiter
+	}
+	if pairDelimiter == "" {
+		pairDelimiter = parseKeyValueDefaultPairDelimiter
+	}
+	return fieldDelimiter, pairDelimiter
+}
+
+func (p LoggingProcessorParseKeyValue) quoteChars() []string {
+	if p.QuoteChar == "" {
+		return []string{`"`, "'"}
+	}
+	return []string{p.QuoteChar}
+}
+
+// parseKeyValueReservedChars cannot be used as a quote character.
+// "$" would need escaping in the OpenTelemetry configuration, and the others are used as placeholders.
+const parseKeyValueReservedChars = "$" + parseKeyValueDoubleQuotePlaceholder + parseKeyValueSingleQuotePlaceholder
+
+func validateParseKeyValue(sl validator.StructLevel) {
+	p := sl.Current().Interface().(LoggingProcessorParseKeyValue)
+	fieldDelimiter, pairDelimiter := p.delimiters()
+	if fieldDelimiter == pairDelimiter {
+		sl.ReportError(reflect.ValueOf(p.PairDelimiter), "pair_delimiter", "PairDelimiter", "nefield", "field_delimiter")
+		return
+	}
+	if strings.ContainsAny(p.QuoteChar, parseKeyValueReservedChars) {
+		sl.ReportError(reflect.ValueOf(p.QuoteChar), "quote_char", "QuoteChar", "excludesall", parseKeyValueReservedChars)
+		return
+	}
+	if p.QuoteChar != "" && strings.Contains(fieldDelimiter+pairDelimiter, p.QuoteChar) {
+		sl.ReportError(reflect.ValueOf(p.QuoteChar), "quote_char", "QuoteChar", "excludesall", fieldDelimiter+pairDelimiter)
+	}
+}
+
+func (p LoggingProcessorParseKeyValue) Components(ctx context.Context, tag, uid string) []fluentbit.Component {
+	field := p.Field
+	if field == "" {
+		field = "message"
+	}
+	fieldDelimiter, pairDelimiter := p.delimiters()
+	var quotes []string
+	for _, q := range p.quoteChars() {
+		quotes = append(quotes, fmt.Sprintf("[%s] = true", filter.LuaQuote(q)))
+	}
+	var types []string
+	for _, k := range GetSortedKeys(p.Types) {
+		types = append(types, fmt.Sprintf("[%s] = %s", filter.LuaQuote(k), filter.LuaQuote(p.Types[k])))
+	}
+	lua := fmt.Sprintf(`
+local field = %s
+local field_delimiter = %s
+local pair_delimiter = %s
+local quotes = {%s}
+local types = {%s}
+`, filter.LuaQuote(field), filter.LuaQuote(fieldDelimiter), filter.LuaQuote(pairDelimiter), strings.Join(quotes, ", "), strings.Join(types, ", "))
+
+	parser, parserName := fluentbit.ParserComponentBase(p.TimeFormat, p.TimeKey, nil, tag, uid)
+	parser.Config["Format"] = "json"
+
+	components := fluentbit.LuaFilterComponents(tag, "parse_key_value", lua+parserLuaJSON+parseKeyValueLua)
+	components = append(components, fluentbit.ParserFilterComponents(tag, p.Field, []string{parserName}, false)...)
+	components = append(components, parser)
+	return components
+}
+
+func (p LoggingProcessorParseKeyValue) Processors(ctx context.Context) ([]otel.Component, error) {
+	from := p.Field
+	if from == "" {
+		from = "jsonPayload.message"
+	}
+	m, err := filter.NewMemberLegacy(from)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAccessor, err := m.OTTLAccessor()
+	if err != nil {
+		return nil, err
+	}
+
+	fieldDelimiter, pairDelimiter := p.delimiters()
+	row := ottl.LValue{"cache", "__key_value_row"}
+	cachedPairs := ottl.LValue{"cache", "__parsed_key_value"}
+	statements := ottl.NewStatements(
+		row.SetIf(fromAccessor, ottl.And(fromAccessor.IsPresent(), fromAccessor.IsString())),
+	)
+	// placeholders maps the quotes that ParseKeyValue must not treat as quote characters to their placeholders.
+	placeholders := map[string]string{}
+	if p.QuoteChar != "" && p.QuoteChar != `"` {
+		placeholders[`"`] = parseKeyValueDoubleQuotePlaceholder
+	}
+	if p.QuoteChar != "" && p.QuoteChar != "'" {
+		placeholders["'"] = parseKeyValueSingleQuotePlaceholder
+	}
+	for _, q := range GetSortedKeys(placeholders) {
+		statements = statements.Append(row.ReplacePatternIf(q, ottl.StringLiteral(placeholders[q]), "", "", row.IsPresent()))
+	}
+	if len(placeholders) == 2 {
+		statements = statements.Append(row.ReplacePatternIf(regexp.QuoteMeta(p.QuoteChar), ottl.StringLiteral(`"`), "", "", row.IsPresent()))
+	}
+	statements = statements.Append(
+		cachedPairs.SetIf(ottl.ParseKeyValue(row, fieldDelimiter, pairDelimiter), row.IsPresent()),
+	)
+	for _, q := range GetSortedKeys(placeholders) {
+		present := cachedPairs.IsPresent()
+		statements = statements.Append(
+			cachedPairs.ReplaceAllPatternsIf("key", placeholders[q], ottl.StringLiteral(q), "", "", present),
+			cachedPairs.ReplaceAllPatternsIf("value", placeholders[q], ottl.StringLiteral(q), "", "", present),
+		)
+	}
+	statements = statements.Append(
+		fromAccessor.DeleteIf(cachedPairs.IsPresent()),
+		ottl.LValue{"body"}.MergeMapsIf(cachedPairs, "upsert", cachedPairs.IsPresent()),
+		cachedPairs.Delete(),
+		row.Delete(),
+	)
+
+	shared := p.ParserShared
+	shared.Types = p.Types
+	ts, err := shared.TimestampStatements()
+	if err != nil {
+		return nil, err
+	}
+	statements = statements.Append(ts)
+	ts, err = shared.TypesStatements()
+	if err != nil {
+		return nil, err
+	}
+	statements = statements.Append(ts)
+
+	statements = statements.Append(shared.FluentBitSpecialFieldsStatements(ctx))
+
+	return []otel.Component{otel.Transform(
+		"log", "log",
+		statements,
+	)}, nil
+}
+
+func init() {
+	LoggingProcessorTypes.RegisterType(func() LoggingProcessor { return &LoggingProcessorParseKeyValue{} })
+}
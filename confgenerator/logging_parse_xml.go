@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confgenerator
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/filter"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/fluentbit"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel/ottl"
+)
+
+const parseXMLDefaultTextKey = "value"
+
+// A LoggingProcessorParseXML parses the specified field as XML, using OTTL's ParseSimplifiedXML.
+// Elements become fields, and repeated elements become lists. Attributes and text mixed with
+// child elements are dropped, unless FlattenAttributes or TextKey are set.
+// There is no Fluent Bit implementation, which is enforced by ValidateBackend.
+type LoggingProcessorParseXML struct {
+	ConfigComponent `yaml:",inline"`
+	ParserShared    `yaml:",inline"`
+	Field           string `yaml:"field,omitempty" validate:"omitempty,fieldlegacy"`
+	// Target is the field that receives the parsed document. By default, it is merged into jsonPayload.
+	Target string `yaml:"target,omitempty" validate:"omitempty,field,startswith=jsonPayload."`
+	// FlattenAttributes turns attributes into child elements, so <a id="1">x</a> becomes {"a": {"id": "1", "value": "x"}}.
+	FlattenAttributes bool `yaml:"flatten_attributes,omitempty"`
+	// TextKey names the child element that holds text mixed with child elements. Defaults to "value" if FlattenAttributes is set.
+	TextKey string `yaml:"text_key,omitempty" validate:"omitempty,excludesall=/[]@:*()"`
+}
+
+func (p LoggingProcessorParseXML) Type() string {
+	return "parse_xml"
+}
+
+func (p LoggingProcessorParseXML) Components(ctx context.Context, tag, uid string) []fluentbit.Component {
+	// Configs that use this processor with the Fluent Bit backend are rejected by validation.
+	return nil
+}
+
+func (p LoggingProcessorParseXML) Processors(ctx context.Context) ([]otel.Component, error) {
+	from := p.Field
+	if from == "" {
+		from = "jsonPayload.message"
+	}
+	m, err := filter.NewMemberLegacy(from)
+	if err != nil {
+		return nil, err
+	}
+	fromAccessor, err := m.OTTLAccessor()
+	if err != nil {
+		return nil, err
+	}
+
+	var xml ottl.Value = fromAccessor
+	if p.FlattenAttributes {
+		xml = ottl.ConvertAttributesToElementsXML(xml)
+	}
+	if textKey := p.TextKey; textKey != "" || p.FlattenAttributes {
+		if textKey == "" {
+			textKey = parseXMLDefaultTextKey
+		}
+		xml = ottl.ConvertTextToElementsXML(xml, textKey)
+	}
+
+	cachedXML := ottl.LValue{"cache", "__parsed_xml"}
+	statements := ottl.NewStatements(
+		cachedXML.SetIf(ottl.ParseSimplifiedXML(xml), ottl.And(fromAccessor.IsPresent(), fromAccessor.IsString())),
+		fromAccessor.DeleteIf(cachedXML.IsPresent()),
+	)
+	if p.Target == "" {
+		statements = statements.Append(ottl.LValue{"body"}.MergeMapsIf(cachedXML, "upsert", cachedXML.IsPresent()))
+	} else {
+		t, err := filter.NewMember(p.Target)
+		if err != nil {
+			return nil, err
+		}
+		target, err := t.OTTLAccessor()
+		if err != nil {
+			return nil, err
+		}
+		statements = statements.Append(target.SetIf(cachedXML, cachedXML.IsPresent()))
+	}
+	statements = statements.Append(cachedXML.Delete())
+
+	ts, err := p.TimestampStatements()
+	if err != nil {
+		return nil, err
+	}
+	statements = statements.Append(ts)
+	ts, err = p.TypesStatements()
+	if err != nil {
+		return nil, err
+	}
+	statements = statements.Append(ts)
+
+	statements = statements.Append(p.FluentBitSpecialFieldsStatements(ctx))
+
+	return []otel.Component{otel.Transform(
+		"log", "log",
+		statements,
+	)}, nil
+}
+
+// ValidateBackend rejects the Fluent Bit logging backend, which has no XML parser.
+func (p LoggingProcessorParseXML) ValidateBackend(backend pipelineBackend) error {
+	if backend == BackendFluentBit {
+		return errOTelLoggingOnly
+	}
+	return nil
+}
+
+func init() {
+	LoggingProcessorTypes.RegisterType(func() LoggingProcessor { return &LoggingProcessorParseXML{} })
+}
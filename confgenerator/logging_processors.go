@@ -16,10 +16,13 @@ package confgenerator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/filter"
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/fluentbit"
@@ -792,3 +795,270 @@ func (p LoggingProcessorExcludeLogs) Processors(ctx context.Context) ([]otel.Com
 func init() {
 	LoggingProcessorTypes.RegisterType(func() LoggingProcessor { return &LoggingProcessorExcludeLogs{} })
 }
+
+// sampleLogsBuckets is the number of buckets records are hashed into by sample_logs.
+// The percentage is applied with a granularity of 100/sampleLogsBuckets.
+const sampleLogsBuckets = 10000
+
+// sampleLogsLuaBucket is the Lua equivalent of the OTTL expression returned by sampleLogsOTTLBucket.
+// It computes the 64-bit FNV-1a hash of s, interprets it as a signed integer, and returns the
+// absolute value of its remainder, which matches OTTL's FNV function and integer arithmetic.
+const sampleLogsLuaBucket = `
+local function sample_bucket(s)
+  local h = 14695981039346656037ULL
+  for i = 1, #s do
+    h = bit.bxor(h, s:byte(i)) * 1099511628211ULL
+  end
+  if h >= 0x8000000000000000ULL then
+    h = -h
+  end
+  return tonumber(h %% %dULL)
+end
+`
+
+// A LoggingProcessorSampleLogs keeps a percentage of logs, optionally only considering logs that match a pattern.
+type LoggingProcessorSampleLogs struct {
+	ConfigComponent `yaml:",inline"`
+	Percentage      float64  `yaml:"percentage" validate:"required,min=0.01,max=100"`
+	MatchAny        []string `yaml:"match_any" validate:"omitempty,dive,filter"`
+	HashField       string   `yaml:"hash_field" validate:"omitempty,field"`
+}
+
+func (p LoggingProcessorSampleLogs) Type() string {
+	return "sample_logs"
+}
+
+func (p LoggingProcessorSampleLogs) filters() ([]*filter.Filter, error) {
+	return LoggingProcessorExcludeLogs{MatchAny: p.MatchAny}.filters()
+}
+
+// threshold returns the number of buckets whose records are kept.
+func (p LoggingProcessorSampleLogs) threshold() int {
+	return int(math.Round(p.Percentage * sampleLogsBuckets / 100))
+}
+
+func (p LoggingProcessorSampleLogs) Components(ctx context.Context, tag, uid string) []fluentbit.Component {
+	filters, err := p.filters()
+	if err != nil {
+		panic(err)
+	}
+	var components []fluentbit.Component
+	lua := "local match = true\n"
+	if len(filters) > 0 {
+		components, lua = filter.AllFluentConfig(tag, map[string]*filter.Filter{
+			"match": filter.MatchesAny(filters),
+		})
+	}
+	value := "nil"
+	if p.HashField != "" {
+		m, err := filter.NewMember(p.HashField)
+		if err != nil {
+			panic(err)
+		}
+		value, err = m.LuaAccessor(false)
+		if err != nil {
+			panic(err)
+		}
+	}
+	components = append(components, fluentbit.LuaFilterComponents(
+		tag, "process", fmt.Sprintf(`
+math.randomseed(os.time())
+`+sampleLogsLuaBucket+`
+function process(tag, timestamp, record)
+%s
+  if match then
+    local v = %s
+    local bucket
+    if (type(v) == "string" and v ~= "") or type(v) == "number" then
+      bucket = sample_bucket(tostring(v))
+    else
+      bucket = math.random(0, %d)
+    end
+    if bucket >= %d then
+      return -1, 0, 0
+    end
+  end
+  return 2, 0, record
+end`, sampleLogsBuckets, lua, value, sampleLogsBuckets-1, p.threshold()))...)
+	return components
+}
+
+// sampleLogsOTTLBucket returns an OTTL condition that is true if the hash of v falls into one of
+// the first threshold buckets. OTTL has no modulo operator, so the remainder is computed by hand.
+func sampleLogsOTTLBucket(v ottl.Value, threshold int) ottl.Value {
+	hash := ottl.FNV(ottl.ToString(v))
+	remainder := ottl.Subtract(hash, ottl.Multiply(ottl.Divide(hash, ottl.IntLiteral(sampleLogsBuckets)), ottl.IntLiteral(sampleLogsBuckets)))
+	return ottl.And(
+		ottl.Compare(remainder, ">", ottl.IntLiteral(-threshold)),
+		ottl.Compare(remainder, "<", ottl.IntLiteral(threshold)),
+	)
+}
+
+// sampleLogsOTTLRandom returns an OTTL condition that is true for a random threshold out of sampleLogsBuckets records.
+// UUID() returns a different value every time it is evaluated, so its hash is only compared once, with a limit that
+// splits the range of int64 where FNV hashes are evenly distributed.
+func sampleLogsOTTLRandom(threshold int) ottl.Value {
+	if threshold >= sampleLogsBuckets {
+		return ottl.True()
+	}
+	// Adding 1<<63 wraps around to move the limit from the range of uint64 to the range of int64.
+	limit := int64(uint64(threshold)*(math.MaxUint64/sampleLogsBuckets) + 1<<63)
+	return ottl.Compare(ottl.FNV(ottl.UUID()), "<", ottl.IntLiteral(int(limit)))
+}
+
+func (p LoggingProcessorSampleLogs) Processors(ctx context.Context) ([]otel.Component, error) {
+	filters, err := p.filters()
+	if err != nil {
+		return nil, err
+	}
+	var conditions []ottl.Value
+	if len(filters) > 0 {
+		var expressions []ottl.Value
+		for _, f := range filters {
+			expr, err := f.OTTLExpression()
+			if err != nil {
+				return nil, fmt.Errorf("failed to process condition %q: %w", f, err)
+			}
+			expressions = append(expressions, expr)
+		}
+		conditions = append(conditions, ottl.Or(expressions...))
+	}
+	// There is no random number generator in OTTL; a random UUID is hashed instead, so that every record is
+	// sampled independently, like with math.random in Lua.
+	random := sampleLogsOTTLRandom(p.threshold())
+	if p.HashField != "" {
+		m, err := filter.NewMember(p.HashField)
+		if err != nil {
+			return nil, err
+		}
+		field, err := m.OTTLAccessor()
+		if err != nil {
+			return nil, err
+		}
+		hashed := ottl.And(ottl.IsNotNil(field), ottl.IsNotEmptyString(field))
+		conditions = append(conditions, ottl.Or(
+			ottl.And(hashed, ottl.Not(sampleLogsOTTLBucket(field, p.threshold()))),
+			ottl.And(ottl.Not(hashed), ottl.Not(random)),
+		))
+	} else {
+		conditions = append(conditions, ottl.Not(random))
+	}
+	return []otel.Component{otel.Filter(
+		"logs", "log_record",
+		[]ottl.Value{ottl.And(conditions...)},
+	)}, nil
+}
+
+func init() {
+	LoggingProcessorTypes.RegisterType(func() LoggingProcessor { return &LoggingProcessorSampleLogs{} })
+}
+
+// A LoggingProcessorRateLimitLogs drops logs that exceed a maximum rate, optionally tracked separately for each value of a field.
+// It is only supported by the fluent-bit logging backend: OTTL statements are evaluated independently for each record, and the
+// collector has no processor that tracks a rate across records, so it does not implement OTelProcessor.
+// Fluent Bit's Lua filter only has a clock with a resolution of one second, so the tokens for a whole second are added at once,
+// and it can't run on a timer, so the summary of dropped logs is only emitted with the first log that arrives after
+// summary_interval has elapsed.
+type LoggingProcessorRateLimitLogs struct {
+	ConfigComponent  `yaml:",inline"`
+	RecordsPerSecond int            `yaml:"records_per_second" validate:"required,min=1"`
+	Burst            int            `yaml:"burst" validate:"omitempty,min=1"`
+	KeyField         string         `yaml:"key_field" validate:"omitempty,field"`
+	SummaryInterval  *time.Duration `yaml:"summary_interval,omitempty" validate:"omitempty,min=1s,multipleof_time=1s"`
+}
+
+func (p LoggingProcessorRateLimitLogs) Type() string {
+	return "rate_limit_logs"
+}
+
+// ValidateBackend rejects the OpenTelemetry logging backend, which has no implementation.
+func (p LoggingProcessorRateLimitLogs) ValidateBackend(backend pipelineBackend) error {
+	if backend == BackendOTel {
+		return errors.New("is not supported by the OpenTelemetry logging backend, which cannot limit the rate of logs")
+	}
+	return nil
+}
+
+func (p LoggingProcessorRateLimitLogs) burst() int {
+	if p.Burst == 0 {
+		return p.RecordsPerSecond
+	}
+	return p.Burst
+}
+
+func (p LoggingProcessorRateLimitLogs) summaryInterval() time.Duration {
+	if p.SummaryInterval == nil {
+		return time.Minute
+	}
+	return *p.SummaryInterval
+}
+
+func (p LoggingProcessorRateLimitLogs) Components(ctx context.Context, tag, uid string) []fluentbit.Component {
+	key := `""`
+	var keyLabel string
+	if p.KeyField != "" {
+		m, err := filter.NewMember(p.KeyField)
+		if err != nil {
+			panic(err)
+		}
+		accessor, err := m.LuaAccessor(false)
+		if err != nil {
+			panic(err)
+		}
+		key = fmt.Sprintf("tostring(%s)", accessor)
+		keyLabel = fmt.Sprintf("\n        summary[%s] = k", filter.LuaQuote("rate_limit_key"))
+	}
+	// Each bucket holds up to burst tokens and is refilled at records_per_second; a record is only kept if it can take a token.
+	// Buckets that have been idle for a whole summary_interval are full again, so they are discarded to bound memory usage.
+	return fluentbit.LuaFilterComponents(tag, "process", fmt.Sprintf(`
+local buckets = {}
+local last_summary = os.time()
+
+function process(tag, timestamp, record)
+  local now = os.time()
+  local k = %s
+  local b = buckets[k]
+  if b == nil then
+    b = {tokens = %d, last = now, dropped = 0}
+    buckets[k] = b
+  end
+  b.tokens = math.min(%[2]d, b.tokens + (now - b.last) * %[3]d)
+  b.last = now
+  local keep = b.tokens >= 1
+  if keep then
+    b.tokens = b.tokens - 1
+  else
+    b.dropped = b.dropped + 1
+  end
+  local records = {}
+  if now - last_summary >= %[4]d then
+    last_summary = now
+    for k, b in pairs(buckets) do
+      if b.dropped > 0 then
+        local summary = {}
+        summary["message"] = string.format("Dropped %%d log entries that exceeded the rate limit of %[3]d per second", b.dropped)
+        summary["dropped_count"] = b.dropped
+        summary["logging.googleapis.com/severity"] = "WARNING"%[5]s
+        table.insert(records, summary)
+        b.dropped = 0
+      elseif now - b.last >= %[4]d then
+        buckets[k] = nil
+      end
+    end
+  end
+  if #records == 0 then
+    if keep then
+      return 0, 0, 0
+    end
+    return -1, 0, 0
+  end
+  if keep then
+    table.insert(records, record)
+  end
+  return 2, 0, records
+end`, key, p.burst(), p.RecordsPerSecond, int(p.summaryInterval().Seconds()), keyLabel))
+}
+
+func init() {
+	LoggingProcessorTypes.RegisterType(func() LoggingProcessor { return &LoggingProcessorRateLimitLogs{} })
+}
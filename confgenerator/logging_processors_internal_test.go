@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confgenerator
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSampleLogsOTelRandomSource(t *testing.T) {
+	components, err := LoggingProcessorSampleLogs{Percentage: 10}.Processors(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := fmt.Sprint(components[0].Config)
+	// Every record must be sampled independently, even records that are observed at the same time,
+	// and UUID() must only be evaluated once per record.
+	if strings.Count(config, "UUID()") != 1 || strings.Contains(config, "observed_time") {
+		t.Errorf("sample_logs does not hash a single random UUID: %s", config)
+	}
+}
+
+// TestSampleLogsOTelRate evaluates the condition built by sampleLogsOTTLRandom for random UUIDs, and checks
+// that the share of records that are kept matches the percentage.
+func TestSampleLogsOTelRate(t *testing.T) {
+	const records = 200000
+	for _, percentage := range []float64{0.5, 10, 50, 99} {
+		t.Run(fmt.Sprint(percentage), func(t *testing.T) {
+			condition := sampleLogsOTTLRandom(LoggingProcessorSampleLogs{Percentage: percentage}.threshold()).String()
+			limit, err := strconv.ParseInt(strings.TrimPrefix(condition, "FNV(UUID()) < "), 10, 64)
+			if err != nil {
+				t.Fatalf("unexpected condition %q: %v", condition, err)
+			}
+			kept := 0
+			for range records {
+				h := fnv.New64a()
+				h.Write([]byte(uuid.NewString()))
+				if int64(h.Sum64()) < limit {
+					kept++
+				}
+			}
+			got := 100 * float64(kept) / records
+			// The standard deviation is below 0.12% for 200000 records.
+			if math.Abs(got-percentage) > 0.5 {
+				t.Errorf("kept %.2f%% of the records, want %v%%", got, percentage)
+			}
+		})
+	}
+}
+
+func TestSampleLogsOTelRateAll(t *testing.T) {
+	if got := sampleLogsOTTLRandom(LoggingProcessorSampleLogs{Percentage: 100}.threshold()).String(); got != "true" {
+		t.Errorf("sample_logs with a percentage of 100 = %q, want true", got)
+	}
+}
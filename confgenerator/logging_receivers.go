@@ -17,16 +17,22 @@ package confgenerator
 import (
 	"context"
 	"fmt"
+	"maps"
 	"path"
+	"reflect"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/filter"
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/fluentbit"
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/fluentbit/modify"
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel"
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel/ottl"
 	"github.com/GoogleCloudPlatform/ops-agent/internal/platform"
+	"github.com/go-playground/validator/v10"
 )
 
 // DBPath returns the database path for the given log tag
@@ -272,10 +278,238 @@ func (r LoggingReceiverFilesMixin) MergeInternalLoggingProcessor(p InternalLoggi
 	return r, p
 }
 
+// LoggingFilesReceivers returns a map of receiver IDs to the file settings of
+// all receivers used in a logging pipeline that tail files, including the
+// receivers of third-party applications.
+func (uc *UnifiedConfig) LoggingFilesReceivers(ctx context.Context) (map[string]LoggingReceiverFilesMixin, error) {
+	pipelines, err := uc.loggingPipelines(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]LoggingReceiverFilesMixin{}
+	for _, p := range pipelines {
+		r, ok := p.Receiver.(LoggingReceiverMacro)
+		if !ok {
+			continue
+		}
+		// Expand can't fail; the processors that it returns don't affect
+		// which files the receiver reads.
+		expanded, _ := r.Expand(ctx)
+		var mixin LoggingReceiverFilesMixin
+		switch receiver := expanded.(type) {
+		case LoggingReceiverFilesMixin:
+			mixin = receiver
+		case *LoggingReceiverFilesMixin:
+			mixin = *receiver
+		default:
+			continue
+		}
+		if len(mixin.IncludePaths) > 0 {
+			out[p.RID] = mixin
+		}
+	}
+	return out, nil
+}
+
 func init() {
 	LoggingReceiverTypes.RegisterType(func() LoggingReceiver { return &LoggingReceiverFiles{} })
 }
 
+// syslogSeverities maps syslog severity codes to LogEntry severities.
+var syslogSeverities = []string{"EMERGENCY", "ALERT", "CRITICAL", "ERROR", "WARNING", "NOTICE", "INFO", "DEBUG"}
+
+// syslogLua parses syslog messages into the same fields that are produced by the OTel "syslog" receiver.
+// Fields with a NILVALUE ("-") are omitted, and messages that cannot be parsed are left unchanged.
+const syslogLua = `
+local severities = {%s}
+
+local function days_from_civil(y, m, d)
+  if m <= 2 then
+    y = y - 1
+  end
+  local era = math.floor(y / 400)
+  local yoe = y - era * 400
+  local doy = math.floor((153 * ((m + 9) %% 12) + 2) / 5) + d - 1
+  local doe = yoe * 365 + math.floor(yoe / 4) - math.floor(yoe / 100) + doy
+  return era * 146097 + doe - 719468
+end
+
+-- parse_rfc3339 returns the number of seconds since the epoch for an RFC 3339 timestamp.
+local function parse_rfc3339(s)
+  local y, mo, d, h, mi, sec, frac, tz = s:match("^(%%d%%d%%d%%d)%%-(%%d%%d)%%-(%%d%%d)T(%%d%%d):(%%d%%d):(%%d%%d)(%%.?%%d*)(.*)$")
+  if y == nil then
+    return nil
+  end
+  local offset = 0
+  if tz ~= "Z" then
+    local sign, oh, om = tz:match("^([+-])(%%d%%d):(%%d%%d)$")
+    if sign == nil then
+      return nil
+    end
+    offset = (tonumber(oh) * 60 + tonumber(om)) * 60
+    if sign == "-" then
+      offset = -offset
+    end
+  end
+  local t = days_from_civil(tonumber(y), tonumber(mo), tonumber(d)) * 86400 + tonumber(h) * 3600 + tonumber(mi) * 60 + tonumber(sec) - offset
+  if frac ~= "" then
+    t = t + tonumber("0" .. frac)
+  end
+  return t
+end
+
+local months = {Jan = 1, Feb = 2, Mar = 3, Apr = 4, May = 5, Jun = 6, Jul = 7, Aug = 8, Sep = 9, Oct = 10, Nov = 11, Dec = 12}
+
+-- parse_rfc3164_time returns the number of seconds since the epoch for a timestamp like "Oct 11 22:14:15" in UTC in the current year.
+local function parse_rfc3164_time(mon, d, h, mi, sec)
+  local m = months[mon]
+  if m == nil then
+    return nil
+  end
+  local y = os.date("!*t").year
+  return days_from_civil(y, m, tonumber(d)) * 86400 + tonumber(h) * 3600 + tonumber(mi) * 60 + tonumber(sec)
+end
+
+local function nil_value(s)
+  if s == "-" then
+    return nil
+  end
+  return s
+end
+
+-- parse_structured_data parses RFC 5424 structured data at the start of s,
+-- and returns a table of SD-ID => {PARAM-NAME => PARAM-VALUE} and the remainder of s.
+local function parse_structured_data(s)
+  if s:sub(1, 1) == "-" then
+    return nil, s:sub(2)
+  end
+  local sd = {}
+  local i = 1
+  while s:sub(i, i) == "[" do
+    local id, j = s:match("^([^%%s%%]=\"]+)()", i + 1)
+    if id == nil then
+      return nil
+    end
+    local params = {}
+    i = j
+    while s:sub(i, i) == " " do
+      local name, k = s:match("^([^%%s%%]=\"]+)=\"()", i + 1)
+      if name == nil then
+        return nil
+      end
+      local value = {}
+      i = k
+      while true do
+        local c = s:sub(i, i)
+        if c == "" then
+          return nil
+        elseif c == "\\" and s:sub(i + 1, i + 1):match('["\\%%]]') then
+          table.insert(value, s:sub(i + 1, i + 1))
+          i = i + 2
+        elseif c == '"' then
+          i = i + 1
+          break
+        else
+          table.insert(value, c)
+          i = i + 1
+        end
+      end
+      params[name] = table.concat(value)
+    end
+    if s:sub(i, i) ~= "]" then
+      return nil
+    end
+    i = i + 1
+    sd[id] = params
+  end
+  if i == 1 then
+    return nil
+  end
+  return sd, s:sub(i)
+end
+
+local function parse_rfc5424(s)
+  local pri, version, ts, hostname, appname, proc_id, msg_id, rest = s:match("^<(%%d%%d?%%d?)>(%%d%%d?) (%%S+) (%%S+) (%%S+) (%%S+) (%%S+) (.*)$")
+  if pri == nil then
+    return nil
+  end
+  local timestamp
+  if ts ~= "-" then
+    timestamp = parse_rfc3339(ts)
+    if timestamp == nil then
+      return nil
+    end
+  end
+  local sd, message = parse_structured_data(rest)
+  if message == nil then
+    return nil
+  end
+  if message:sub(1, 1) == " " then
+    message = message:sub(2)
+  elseif message ~= "" then
+    return nil
+  end
+  return {
+    priority = tonumber(pri),
+    hostname = nil_value(hostname),
+    appname = nil_value(appname),
+    proc_id = nil_value(proc_id),
+    msg_id = nil_value(msg_id),
+    structured_data = sd,
+    message = message,
+  }, timestamp
+end
+
+local function parse_rfc3164(s)
+  local pri, mon, d, h, mi, sec, hostname, rest = s:match("^<(%%d%%d?%%d?)>(%%a%%a%%a) +(%%d%%d?) (%%d%%d):(%%d%%d):(%%d%%d) (%%S+) ?(.*)$")
+  if pri == nil then
+    return nil
+  end
+  local timestamp = parse_rfc3164_time(mon, d, h, mi, sec)
+  if timestamp == nil then
+    return nil
+  end
+  local appname, proc_id, message = rest:match("^([^%%s%%[:]+)%%[([^%%]]*)%%]: ?(.*)$")
+  if appname == nil then
+    appname, message = rest:match("^([^%%s%%[:]+): ?(.*)$")
+  end
+  if appname == nil then
+    message = rest
+  end
+  return {
+    priority = tonumber(pri),
+    hostname = hostname,
+    appname = appname,
+    proc_id = proc_id,
+    message = message,
+  }, timestamp
+end
+
+function parse_syslog(tag, timestamp, record)
+  local message = record["message"]
+  if type(message) ~= "string" then
+    return 0, 0, 0
+  end
+  local parsed, parsed_timestamp = parse_%s(message)
+  if parsed == nil or parsed.priority > 191 then
+    return 0, 0, 0
+  end
+  parsed.facility = math.floor(parsed.priority / 8)
+  if parsed.message == "" then
+    parsed.message = nil
+  end
+  record["message"] = nil
+  for k, v in pairs(parsed) do
+    record[k] = v
+  end
+  record["logging.googleapis.com/severity"] = severities[parsed.priority %% 8 + 1]
+  if parsed_timestamp ~= nil then
+    return 1, parsed_timestamp, record
+  end
+  return 2, 0, record
+end
+`
+
 // A LoggingReceiverSyslog represents the configuration for a syslog protocol receiver.
 type LoggingReceiverSyslog struct {
 	ConfigComponent `yaml:",inline"`
@@ -283,6 +517,10 @@ type LoggingReceiverSyslog struct {
 	TransportProtocol string `yaml:"transport_protocol,omitempty" validate:"oneof=tcp udp"`
 	ListenHost        string `yaml:"listen_host,omitempty" validate:"required,ip"`
 	ListenPort        uint16 `yaml:"listen_port,omitempty" validate:"required"`
+	// Protocol selects how messages are parsed. "raw" (the default) stores the whole message in jsonPayload.message.
+	Protocol string `yaml:"protocol,omitempty" validate:"omitempty,oneof=rfc3164 rfc5424 raw"`
+	// TLS is only supported with the "tcp" transport protocol.
+	TLS *LoggingReceiverSharedTLS `yaml:"tls,omitempty"`
 }
 
 func (r LoggingReceiverSyslog) Type() string {
@@ -293,8 +531,19 @@ func (r LoggingReceiverSyslog) GetListenPort() uint16 {
 	return r.ListenPort
 }
 
+func (r LoggingReceiverSyslog) GetListenAddress() ListenAddress {
+	return ListenAddress{Protocol: r.TransportProtocol, Host: r.ListenHost, Port: r.GetListenPort()}
+}
+
+func validateLoggingReceiverSyslog(sl validator.StructLevel) {
+	r := sl.Current().Interface().(LoggingReceiverSyslog)
+	if r.TLS != nil && r.TransportProtocol != "tcp" {
+		sl.ReportError(reflect.ValueOf(r.TLS), "tls", "TLS", "excluded_unless", "transport_protocol tcp")
+	}
+}
+
 func (r LoggingReceiverSyslog) Components(ctx context.Context, tag string) []fluentbit.Component {
-	return []fluentbit.Component{{
+	c := []fluentbit.Component{{
 		Kind: "INPUT",
 		Config: map[string]string{
 			// https://docs.fluentbit.io/manual/pipeline/inputs/syslog
@@ -316,7 +565,8 @@ func (r LoggingReceiverSyslog) Components(ctx context.Context, tag string) []flu
 			"Mem_Buf_Limit": "10M",
 		},
 	}, {
-		// FIXME: This is not new, but we shouldn't be disabling syslog protocol parsing by passing a custom Parser - Fluentbit includes builtin syslog protocol support, and we should enable/expose that.
+		// The message is parsed by a Lua filter rather than by the syslog input, so that messages
+		// that do not match the protocol are kept instead of being dropped.
 		Kind: "PARSER",
 		Config: map[string]string{
 			"Name":   tag,
@@ -324,6 +574,37 @@ func (r LoggingReceiverSyslog) Components(ctx context.Context, tag string) []flu
 			"Regex":  `^(?<message>.*)$`,
 		},
 	}}
+	maps.Copy(c[0].Config, r.TLS.FluentBitConfig())
+	if r.Protocol == "" || r.Protocol == "raw" {
+		return c
+	}
+	return append(c, syslogFluentBitComponents(tag, r.Protocol)...)
+}
+
+// syslogFluentBitComponents returns the filters that parse the "message" field of records with protocol.
+func syslogFluentBitComponents(tag, protocol string) []fluentbit.Component {
+	var severities []string
+	for _, s := range syslogSeverities {
+		severities = append(severities, filter.LuaQuote(s))
+	}
+	return fluentbit.LuaFilterComponents(tag, "parse_syslog", fmt.Sprintf(syslogLua, strings.Join(severities, ", "), protocol))
+}
+
+// syslogOTTLStatements moves the fields that the OTel syslog parser stored in parsed to the body,
+// in the same format as syslogLua.
+func syslogOTTLStatements(parsed ottl.LValue) ottl.Statements {
+	// Messages that were parsed successfully always have a priority.
+	isParsed := ottl.IsNotNil(append(slices.Clone(parsed), "priority"))
+	statements := ottl.LValue{"body", "message"}.DeleteIf(isParsed)
+	for _, field := range []string{"message", "hostname", "appname", "proc_id", "msg_id", "structured_data", "priority", "facility"} {
+		from := append(slices.Clone(parsed), field)
+		statements = statements.Append(ottl.LValue{"body", field}.SetIf(from, from.IsPresent()))
+	}
+	severity := append(slices.Clone(parsed), "severity")
+	for code, s := range syslogSeverities {
+		statements = statements.Append(ottl.LValue{"severity_text"}.SetIf(ottl.StringLiteral(s), ottl.And(isParsed, ottl.Equals(severity, ottl.IntLiteral(code)))))
+	}
+	return statements
 }
 
 func (r LoggingReceiverSyslog) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
@@ -333,33 +614,45 @@ func (r LoggingReceiverSyslog) Pipelines(ctx context.Context) ([]otel.ReceiverPi
 	cacheBodyMap := ottl.LValue{"cache", "__body_map"}
 	attributes := ottl.LValue{"attributes"}
 
+	// Transformations required to convert "syslogreceiver" output to the expected ops agent "syslog" LogEntry format.
+	statements := ottl.NewStatements(
+		// "syslogreceiver" sets the incoming log as "body" of type "string".
+		cacheBodyString.SetIf(body, body.IsString()),
+		// Preserve any existing fields in "body" just in case.
+		cacheBodyMap.SetIf(body, body.IsMap()),
+		body.Set(ottl.RValue("{}")),
+		body.MergeMapsIf(cacheBodyMap, "upsert", cacheBodyMap.IsPresent()),
+		// Move "body" to "body.message" (jsonPayload.message)
+		bodyMessage.SetIf(cacheBodyString, cacheBodyString.IsPresent()),
+	)
+	protocol := r.Protocol
+	if protocol == "" || protocol == "raw" {
+		// The receiver requires a protocol, but the parsed fields are discarded below.
+		protocol = "rfc5424"
+	} else {
+		statements = statements.Append(syslogOTTLStatements(attributes))
+	}
+	statements = statements.Append(
+		// Clear any possible parsed fields added to "attributes".
+		attributes.Set(ottl.RValue("{}")),
+		// Clear cache.
+		cacheBodyMap.Delete(),
+		cacheBodyString.Delete(),
+	)
+
 	processors := []otel.Component{
-		otel.Transform(
-			"log", "log",
-			// Transformations required to convert "syslogreceiver" output to the expected ops agent "syslog" LogEntry format.
-			ottl.NewStatements(
-				// "syslogreceiver" sets the incoming log as "body" of type "string".
-				cacheBodyString.SetIf(body, body.IsString()),
-				// Preserve any existing fields in "body" just in case.
-				cacheBodyMap.SetIf(body, body.IsMap()),
-				body.Set(ottl.RValue("{}")),
-				body.MergeMapsIf(cacheBodyMap, "upsert", cacheBodyMap.IsPresent()),
-				// Move "body" to "body.message" (jsonPayload.message)
-				bodyMessage.SetIf(cacheBodyString, cacheBodyString.IsPresent()),
-				// Clear any possible parsed fields added to "attributes".
-				attributes.Set(ottl.RValue("{}")),
-				// Clear cache.
-				cacheBodyMap.Delete(),
-				cacheBodyString.Delete(),
-			),
-		),
+		otel.Transform("log", "log", statements),
 	}
 
+	transport := map[string]any{
+		"listen_address": fmt.Sprintf("%s:%d", r.ListenHost, r.ListenPort),
+	}
+	if r.TLS != nil {
+		transport["tls"] = r.TLS.OTelConfig()
+	}
 	config := map[string]any{
-		r.TransportProtocol: map[string]any{
-			"listen_address": fmt.Sprintf("%s:%d", r.ListenHost, r.ListenPort),
-		},
-		"protocol": "rfc5424",
+		r.TransportProtocol: transport,
+		"protocol":          protocol,
 	}
 
 	return []otel.ReceiverPipeline{ConvertToOtlpExporter(otel.ReceiverPipeline{
@@ -377,6 +670,45 @@ func (r LoggingReceiverSyslog) Pipelines(ctx context.Context) ([]otel.ReceiverPi
 	}, ctx, false, false)}, nil
 }
 
+// LoggingProcessorParseSyslog parses the "message" field like a syslog receiver with Protocol.
+// It is not registered, and is only used to test the syslog parsers against log files.
+type LoggingProcessorParseSyslog struct {
+	ConfigComponent `yaml:",inline"`
+
+	Protocol string `yaml:"protocol" validate:"required,oneof=rfc3164 rfc5424"`
+}
+
+func (p LoggingProcessorParseSyslog) Type() string {
+	return "parse_syslog"
+}
+
+func (p LoggingProcessorParseSyslog) Components(ctx context.Context, tag, uid string) []fluentbit.Component {
+	return syslogFluentBitComponents(tag, p.Protocol)
+}
+
+func (p LoggingProcessorParseSyslog) Processors(ctx context.Context) ([]otel.Component, error) {
+	parsed := ottl.LValue{"attributes", "__syslog"}
+	return []otel.Component{
+		{
+			Type: "logstransform",
+			Config: map[string]any{
+				"operators": []map[string]any{{
+					"type":       "syslog_parser",
+					"protocol":   p.Protocol,
+					"parse_from": "body.message",
+					"parse_to":   "attributes.__syslog",
+					// Messages that cannot be parsed are left unchanged, like with syslogLua.
+					"on_error": "send_quiet",
+				}},
+			},
+		},
+		otel.Transform("log", "log", ottl.NewStatements(
+			syslogOTTLStatements(parsed),
+			parsed.Delete(),
+		)),
+	}, nil
+}
+
 func init() {
 	LoggingReceiverTypes.RegisterType(func() LoggingReceiver { return &LoggingReceiverSyslog{} })
 }
@@ -385,9 +717,10 @@ func init() {
 type LoggingReceiverTCP struct {
 	ConfigComponent `yaml:",inline"`
 
-	Format     string `yaml:"format,omitempty" validate:"required,oneof=json"`
-	ListenHost string `yaml:"listen_host,omitempty" validate:"omitempty,ip"`
-	ListenPort uint16 `yaml:"listen_port,omitempty"`
+	Format     string                    `yaml:"format,omitempty" validate:"required,oneof=json"`
+	ListenHost string                    `yaml:"listen_host,omitempty" validate:"omitempty,ip"`
+	ListenPort uint16                    `yaml:"listen_port,omitempty"`
+	TLS        *LoggingReceiverSharedTLS `yaml:"tls,omitempty"`
 }
 
 func (r LoggingReceiverTCP) Type() string {
@@ -401,12 +734,19 @@ func (r LoggingReceiverTCP) GetListenPort() uint16 {
 	return r.ListenPort
 }
 
+func (r LoggingReceiverTCP) GetListenAddress() ListenAddress {
+	if r.ListenHost == "" {
+		r.ListenHost = "127.0.0.1"
+	}
+	return ListenAddress{Protocol: "tcp", Host: r.ListenHost, Port: r.GetListenPort()}
+}
+
 func (r LoggingReceiverTCP) Components(ctx context.Context, tag string) []fluentbit.Component {
 	if r.ListenHost == "" {
 		r.ListenHost = "127.0.0.1"
 	}
 
-	return []fluentbit.Component{{
+	c := []fluentbit.Component{{
 		Kind: "INPUT",
 		Config: map[string]string{
 			// https://docs.fluentbit.io/manual/pipeline/inputs/tcp
@@ -431,6 +771,8 @@ func (r LoggingReceiverTCP) Components(ctx context.Context, tag string) []fluent
 			"Chunk_Size": "512k",
 		},
 	}}
+	maps.Copy(c[0].Config, r.TLS.FluentBitConfig())
+	return c
 }
 
 func init() {
@@ -441,8 +783,9 @@ func init() {
 type LoggingReceiverFluentForward struct {
 	ConfigComponent `yaml:",inline"`
 
-	ListenHost string `yaml:"listen_host,omitempty" validate:"omitempty,ip"`
-	ListenPort uint16 `yaml:"listen_port,omitempty"`
+	ListenHost string                    `yaml:"listen_host,omitempty" validate:"omitempty,ip"`
+	ListenPort uint16                    `yaml:"listen_port,omitempty"`
+	TLS        *LoggingReceiverSharedTLS `yaml:"tls,omitempty"`
 }
 
 func (r LoggingReceiverFluentForward) Type() string {
@@ -456,12 +799,19 @@ func (r LoggingReceiverFluentForward) GetListenPort() uint16 {
 	return r.ListenPort
 }
 
+func (r LoggingReceiverFluentForward) GetListenAddress() ListenAddress {
+	if r.ListenHost == "" {
+		r.ListenHost = "127.0.0.1"
+	}
+	return ListenAddress{Protocol: "tcp", Host: r.ListenHost, Port: r.GetListenPort()}
+}
+
 func (r LoggingReceiverFluentForward) Components(ctx context.Context, tag string) []fluentbit.Component {
 	if r.ListenHost == "" {
 		r.ListenHost = "127.0.0.1"
 	}
 
-	return []fluentbit.Component{{
+	c := []fluentbit.Component{{
 		Kind: "INPUT",
 		Config: map[string]string{
 			// https://docs.fluentbit.io/manual/pipeline/inputs/forward
@@ -481,9 +831,14 @@ func (r LoggingReceiverFluentForward) Components(ctx context.Context, tag string
 			"Mem_Buf_Limit": "10M",
 		},
 	}}
+	maps.Copy(c[0].Config, r.TLS.FluentBitConfig())
+	return c
 }
 
 func (r LoggingReceiverFluentForward) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
+	if r.TLS != nil {
+		return nil, fmt.Errorf("setting tls in otel fluent_forward receiver is not supported")
+	}
 	body := ottl.LValue{"body"}
 	bodyMessage := ottl.LValue{"body", "message"}
 	attributes := ottl.LValue{"attributes"}
@@ -965,25 +1320,82 @@ func init() {
 	LoggingReceiverTypes.RegisterType(func() LoggingReceiver { return &LoggingReceiverWindowsEventLog{} }, platform.Windows)
 }
 
+// journaldPriorities lists the journal priority names, indexed by their numeric value.
+var journaldPriorities = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
 // A LoggingReceiverSystemd represents the user configuration for a Systemd/journald receiver.
 type LoggingReceiverSystemd struct {
 	ConfigComponent `yaml:",inline"`
+
+	// Units restricts the receiver to entries from any of these systemd units. Units without a type suffix are assumed to be services.
+	Units []string `yaml:"units,omitempty" validate:"omitempty,unique,dive,required,excludesall= ="`
+	// Matches restricts the receiver to entries whose journal fields have all of these values.
+	Matches map[string]string `yaml:"matches,omitempty" validate:"omitempty,dive,keys,required,uppercase,excludesall= =,endkeys"`
+	// MinPriority drops entries that are less severe than this priority.
+	MinPriority      string `yaml:"min_priority,omitempty" validate:"omitempty,oneof=emerg alert crit err warning notice info debug"`
+	JournalDirectory string `yaml:"journal_directory,omitempty" validate:"omitempty,startswith=/"`
+	// StartAt selects where to start reading the journal the first time the receiver runs. Defaults to "beginning".
+	StartAt string `yaml:"start_at,omitempty" validate:"omitempty,oneof=beginning end"`
 }
 
 func (r LoggingReceiverSystemd) Type() string {
 	return "systemd_journald"
 }
 
+// units returns the configured units, with ".service" appended to the ones without a type suffix, like journalctl does.
+func (r LoggingReceiverSystemd) units() []string {
+	var units []string
+	for _, u := range r.Units {
+		if !strings.Contains(u, ".") {
+			u += ".service"
+		}
+		units = append(units, u)
+	}
+	return units
+}
+
 func (r LoggingReceiverSystemd) Components(ctx context.Context, tag string) []fluentbit.Component {
+	config := map[string]string{
+		// https://docs.fluentbit.io/manual/pipeline/inputs/systemd
+		"Name": "systemd",
+		"Tag":  tag,
+		"DB":   DBPath(tag),
+	}
+	if r.JournalDirectory != "" {
+		config["Path"] = r.JournalDirectory
+	}
+	if r.StartAt == "end" {
+		config["Read_From_Tail"] = "On"
+	}
+	// Entries for the same journal field are ORed together by the journal.
+	var systemdFilters [][2]string
+	for _, u := range r.units() {
+		systemdFilters = append(systemdFilters, [2]string{"Systemd_Filter", fmt.Sprintf("_SYSTEMD_UNIT=%s", u)})
+	}
 	input := []fluentbit.Component{{
-		Kind: "INPUT",
-		Config: map[string]string{
-			// https://docs.fluentbit.io/manual/pipeline/inputs/systemd
-			"Name": "systemd",
-			"Tag":  tag,
-			"DB":   DBPath(tag),
-		},
+		Kind:          "INPUT",
+		Config:        config,
+		OrderedConfig: systemdFilters,
 	}}
+	// Systemd_Filter cannot express units that are ORed together and then ANDed with other fields,
+	// so the remaining conditions are applied with a grep filter, which requires all of its rules to match.
+	var rules [][2]string
+	for _, k := range GetSortedKeys(r.Matches) {
+		rules = append(rules, [2]string{"Regex", fmt.Sprintf("%s ^%s$", k, regexp.QuoteMeta(r.Matches[k]))})
+	}
+	if r.MinPriority != "" {
+		rules = append(rules, [2]string{"Regex", fmt.Sprintf("PRIORITY ^[0-%d]$", slices.Index(journaldPriorities, r.MinPriority))})
+	}
+	if len(rules) > 0 {
+		input = append(input, fluentbit.Component{
+			Kind: "FILTER",
+			Config: map[string]string{
+				"Name":  "grep",
+				"Match": tag,
+			},
+			OrderedConfig: rules,
+		})
+	}
 	filters := fluentbit.TranslationComponents(tag, "PRIORITY", "logging.googleapis.com/severity", false,
 		[]struct{ SrcVal, DestVal string }{
 			{"7", "DEBUG"},
@@ -1038,11 +1450,29 @@ func (r LoggingReceiverSystemd) Components(ctx context.Context, tag string) []fl
 }
 
 func (r LoggingReceiverSystemd) Pipelines(ctx context.Context) ([]otel.ReceiverPipeline, error) {
+	startAt := r.StartAt
+	if startAt == "" {
+		startAt = "beginning"
+	}
+	priority := r.MinPriority
+	if priority == "" {
+		priority = "debug"
+	}
 	receiver_config := map[string]any{
-		"start_at": "beginning",
-		"priority": "debug",
+		"start_at": startAt,
+		"priority": priority,
 		"storage":  fileStorageExtensionType,
 	}
+	if units := r.units(); len(units) > 0 {
+		receiver_config["units"] = units
+	}
+	if len(r.Matches) > 0 {
+		// Each element of "matches" is a set of fields that must all match.
+		receiver_config["matches"] = []map[string]string{r.Matches}
+	}
+	if r.JournalDirectory != "" {
+		receiver_config["directory"] = r.JournalDirectory
+	}
 
 	modify_fields_processors, err := LoggingProcessorModifyFields{
 		Fields: map[string]*ModifyField{
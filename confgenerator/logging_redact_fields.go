@@ -0,0 +1,628 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confgenerator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/filter"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/fluentbit"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel/ottl"
+)
+
+const redactDefaultMask = "[REDACTED]"
+
+// A redactDetector describes a built-in kind of sensitive data.
+type redactDetector struct {
+	// RE2 regular expression used by OTel; if empty, the detector is only supported by Fluent Bit.
+	regex string
+	// Lua pattern used by Fluent Bit; if empty, regex is translated with luaPattern.
+	luaPattern string
+	// Name of a Lua function (defined in redactLuaFunctions) that must return true for a match to be redacted.
+	luaCheck string
+	// Name of a Lua function (defined in redactLuaFunctions) that is called with each match and the replace
+	// function, and returns the match with its sensitive parts replaced. It takes precedence over luaCheck.
+	luaReplace string
+}
+
+const redactHex = `[0-9A-Fa-f]{1,4}`
+
+var redactDetectors = map[string]redactDetector{
+	"email": {
+		regex: `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+	},
+	"ipv4": {
+		regex: `[0-9]{1,3}\.[0-9]{1,3}\.[0-9]{1,3}\.[0-9]{1,3}`,
+	},
+	"ipv6": {
+		// Alternatives are ordered so that the longest form wins, since RE2 prefers the leftmost alternative.
+		regex: strings.NewReplacer("H", redactHex).Replace(strings.Join([]string{
+			`\b(?:H:){7}H\b`,
+			`\bH:(?::H){1,6}\b`,
+			`\b(?:H:){1,2}(?::H){1,5}\b`,
+			`\b(?:H:){1,3}(?::H){1,4}\b`,
+			`\b(?:H:){1,4}(?::H){1,3}\b`,
+			`\b(?:H:){1,5}(?::H){1,2}\b`,
+			`\b(?:H:){1,6}:H\b`,
+			`:(?::H){1,7}\b`,
+			`\b(?:H:){1,7}:`,
+		}, "|")),
+		luaPattern: `%f[%w:][%x:]+%f[^%w:]`,
+		luaCheck:   "valid_ipv6",
+	},
+	"credit_card": {
+		// OTTL cannot compute a Luhn checksum, so there is no OTel implementation.
+		// Fluent Bit finds runs of digit groups, and redacts the card numbers within each run.
+		luaPattern: `%f[%w]%d[%d %-]*%d%f[^%w]`,
+		luaReplace: "redact_credit_cards",
+	},
+	"jwt": {
+		regex: `eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`,
+	},
+	"aws_access_key": {
+		regex: `A[KS]IA[0-9A-Z]{16}`,
+	},
+	"gcp_api_key": {
+		regex: `AIza[0-9A-Za-z_-]{35}`,
+	},
+}
+
+var redactLuaFunctions = map[string]string{
+	"valid_ipv6": `
+local function valid_ipv6(s)
+  local head, tail = s, nil
+  local i = string.find(s, "::", 1, true)
+  if i then
+    head, tail = string.sub(s, 1, i - 1), string.sub(s, i + 2)
+    if string.find(tail, "::", 1, true) then
+      return false
+    end
+  end
+  local n = 0
+  for _, part in ipairs({head, tail}) do
+    if part ~= "" then
+      for g in string.gmatch(part .. ":", "(.-):") do
+        if #g < 1 or #g > 4 then
+          return false
+        end
+        n = n + 1
+      end
+    end
+  end
+  if i then
+    return n > 0 and n <= 7
+  end
+  return n == 8
+end
+`,
+	"redact_credit_cards": `
+local function valid_luhn(digits)
+  local sum = 0
+  for i = #digits, 1, -1 do
+    local d = tonumber(string.sub(digits, i, i))
+    if (#digits - i) % 2 == 1 then
+      d = d * 2
+      if d > 9 then
+        d = d - 9
+      end
+    end
+    sum = sum + d
+  end
+  return sum % 10 == 0
+end
+
+-- Card numbers are made of whole digit groups separated by single spaces or hyphens,
+-- with 13 to 19 digits in total and a valid Luhn checksum. The longest one that starts
+-- at each group is redacted.
+local function redact_credit_cards(s, replace)
+  local groups = {}
+  for first, last in string.gmatch(s, "()%d+()") do
+    table.insert(groups, {first, last - 1})
+  end
+  local out, pos, i = {}, 1, 1
+  while i <= #groups do
+    local digits, found = "", nil
+    for j = i, #groups do
+      if j > i and groups[j][1] ~= groups[j - 1][2] + 2 then
+        break
+      end
+      digits = digits .. string.sub(s, groups[j][1], groups[j][2])
+      if #digits > 19 then
+        break
+      end
+      if #digits >= 13 and valid_luhn(digits) then
+        found = j
+      end
+    end
+    if found then
+      table.insert(out, string.sub(s, pos, groups[i][1] - 1))
+      table.insert(out, replace(string.sub(s, groups[i][1], groups[found][2])))
+      pos = groups[found][2] + 1
+      i = found + 1
+    else
+      i = i + 1
+    end
+  end
+  table.insert(out, string.sub(s, pos))
+  return table.concat(out)
+end
+`,
+}
+
+// redactLuaSHA256 computes the hex-encoded SHA-256 digest of a string, matching OTTL's SHA256 function.
+const redactLuaSHA256 = `
+local sha256_k = {
+  0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
+  0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3, 0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
+  0xe49b69c1, 0xefbe4786, 0x0fc19dc6, 0x240ca1cc, 0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+  0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7, 0xc6e00bf3, 0xd5a79147, 0x06ca6351, 0x14292967,
+  0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13, 0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85,
+  0xa2bfe8a1, 0xa81a664b, 0xc24b8b70, 0xc76c51a3, 0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+  0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5, 0x391c0cb3, 0x4ed8aa4a, 0x5b9cca4f, 0x682e6ff3,
+  0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208, 0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
+}
+
+local function sha256(s)
+  local band, bor, bxor, bnot = bit.band, bit.bor, bit.bxor, bit.bnot
+  local lshift, rshift, ror, tobit = bit.lshift, bit.rshift, bit.ror, bit.tobit
+  local h = {0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19}
+  local n = #s
+  s = s .. "\128" .. string.rep("\0", (55 - n) % 64) .. string.char(0, 0, 0, 0,
+    band(rshift(n, 21), 255), band(rshift(n, 13), 255), band(rshift(n, 5), 255), band(lshift(n, 3), 255))
+  local w = {}
+  for i = 1, #s, 64 do
+    for j = 0, 15 do
+      local b1, b2, b3, b4 = string.byte(s, i + j * 4, i + j * 4 + 3)
+      w[j] = bor(lshift(b1, 24), lshift(b2, 16), lshift(b3, 8), b4)
+    end
+    for j = 16, 63 do
+      local s0 = bxor(ror(w[j - 15], 7), ror(w[j - 15], 18), rshift(w[j - 15], 3))
+      local s1 = bxor(ror(w[j - 2], 17), ror(w[j - 2], 19), rshift(w[j - 2], 10))
+      w[j] = tobit(w[j - 16] + s0 + w[j - 7] + s1)
+    end
+    local a, b, c, d, e, f, g, hh = h[1], h[2], h[3], h[4], h[5], h[6], h[7], h[8]
+    for j = 0, 63 do
+      local s1 = bxor(ror(e, 6), ror(e, 11), ror(e, 25))
+      local ch = bxor(band(e, f), band(bnot(e), g))
+      local t1 = tobit(hh + s1 + ch + sha256_k[j + 1] + w[j])
+      local s0 = bxor(ror(a, 2), ror(a, 13), ror(a, 22))
+      local maj = bxor(band(a, b), band(a, c), band(b, c))
+      local t2 = tobit(s0 + maj)
+      hh, g, f, e, d, c, b, a = g, f, e, tobit(d + t1), c, b, a, tobit(t1 + t2)
+    end
+    h[1], h[2], h[3], h[4] = tobit(h[1] + a), tobit(h[2] + b), tobit(h[3] + c), tobit(h[4] + d)
+    h[5], h[6], h[7], h[8] = tobit(h[5] + e), tobit(h[6] + f), tobit(h[7] + g), tobit(h[8] + hh)
+  end
+  for i = 1, 8 do
+    h[i] = bit.tohex(h[i], 8)
+  end
+  return table.concat(h)
+end
+`
+
+// A LoggingProcessorRedactFields replaces sensitive data in string fields with a mask or a hash.
+type LoggingProcessorRedactFields struct {
+	ConfigComponent `yaml:",inline"`
+	// Fields to redact. If empty, the string values of jsonPayload, including those nested in maps and arrays, are
+	// redacted (or the whole payload, if it is a string). OTTL cannot iterate over nested maps, so the OTel backend
+	// requires the fields to be listed.
+	Fields    []string `yaml:"fields" validate:"omitempty,dive,field,writablefield"`
+	Detectors []string `yaml:"detectors" validate:"required_without=Patterns,unique,dive,oneof=aws_access_key credit_card email gcp_api_key ipv4 ipv6 jwt"`
+	// Patterns are RE2 regular expressions restricted to the features that can be translated to Lua patterns.
+	Patterns []string `yaml:"patterns" validate:"omitempty,dive,redactpattern"`
+	Method   string   `yaml:"method" validate:"omitempty,oneof=mask hash"`
+	// Mask replaces matches when Method is "mask" (the default).
+	Mask string `yaml:"mask" validate:"omitempty,excludesall=$"`
+}
+
+func (p LoggingProcessorRedactFields) Type() string {
+	return "redact_fields"
+}
+
+// ValidateBackend rejects the settings that the OTel backend can't implement the same way as Fluent Bit.
+func (p LoggingProcessorRedactFields) ValidateBackend(backend pipelineBackend) error {
+	if backend != BackendOTel {
+		return nil
+	}
+	if len(p.Fields) == 0 {
+		return fmt.Errorf(`must set "fields" on the OpenTelemetry logging backend, which cannot redact nested values of jsonPayload`)
+	}
+	for _, name := range p.Detectors {
+		if redactDetectors[name].regex == "" {
+			return fmt.Errorf(`does not support detector %q on the OpenTelemetry logging backend`, name)
+		}
+	}
+	return nil
+}
+
+func (p LoggingProcessorRedactFields) mask() string {
+	if p.Mask == "" {
+		return redactDefaultMask
+	}
+	return p.Mask
+}
+
+// redactRule is a single regular expression to be redacted, in both OTTL and Lua form.
+type redactRule struct {
+	regex      string
+	luaPattern string
+	luaCheck   string
+	luaReplace string
+}
+
+func (p LoggingProcessorRedactFields) rules() ([]redactRule, error) {
+	var rules []redactRule
+	for _, name := range p.Detectors {
+		d, ok := redactDetectors[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown detector %q", name)
+		}
+		r := redactRule{regex: d.regex, luaPattern: d.luaPattern, luaCheck: d.luaCheck, luaReplace: d.luaReplace}
+		if r.luaPattern == "" {
+			lp, err := luaPattern(d.regex)
+			if err != nil {
+				return nil, fmt.Errorf("failed to translate detector %q: %w", name, err)
+			}
+			r.luaPattern = lp
+		}
+		rules = append(rules, r)
+	}
+	for _, pattern := range p.Patterns {
+		lp, err := luaPattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate pattern %q: %w", pattern, err)
+		}
+		rules = append(rules, redactRule{regex: pattern, luaPattern: lp})
+	}
+	return rules, nil
+}
+
+func (p LoggingProcessorRedactFields) Components(ctx context.Context, tag, uid string) []fluentbit.Component {
+	c, err := p.components(tag)
+	if err != nil {
+		// It shouldn't be possible to get here if the input validation is working, so treat this as a code bug.
+		panic(err)
+	}
+	return c
+}
+
+func (p LoggingProcessorRedactFields) components(tag string) ([]fluentbit.Component, error) {
+	rules, err := p.rules()
+	if err != nil {
+		return nil, err
+	}
+	var lua strings.Builder
+	replace := fmt.Sprintf("return %s", filter.LuaQuote(p.mask()))
+	if p.Method == "hash" {
+		lua.WriteString(redactLuaSHA256)
+		replace = `return "sha256:" .. sha256(m)`
+	}
+	functions := map[string]bool{}
+	for _, r := range rules {
+		for _, f := range []string{r.luaCheck, r.luaReplace} {
+			if f != "" && !functions[f] {
+				functions[f] = true
+				lua.WriteString(redactLuaFunctions[f])
+			}
+		}
+	}
+	fmt.Fprintf(&lua, `
+local function replace(m)
+  %s
+end
+
+local function redact(s)
+`, replace)
+	for _, r := range rules {
+		if r.luaReplace != "" {
+			fmt.Fprintf(&lua, "  s = string.gsub(s, %s, function(m) return %s(m, replace) end)\n", filter.LuaQuote(r.luaPattern), r.luaReplace)
+		} else if r.luaCheck != "" {
+			fmt.Fprintf(&lua, "  s = string.gsub(s, %s, function(m) if %s(m) then return replace(m) end end)\n", filter.LuaQuote(r.luaPattern), r.luaCheck)
+		} else {
+			fmt.Fprintf(&lua, "  s = string.gsub(s, %s, replace)\n", filter.LuaQuote(r.luaPattern))
+		}
+	}
+	lua.WriteString("  return s\nend\n")
+	if len(p.Fields) == 0 {
+		lua.WriteString(`
+local function redact_all(t)
+  for k, v in pairs(t) do
+    if type(v) == "string" then
+      t[k] = redact(v)
+    elseif type(v) == "table" then
+      redact_all(v)
+    end
+  end
+end
+`)
+	}
+	lua.WriteString("\nfunction process(tag, timestamp, record)\n")
+	if len(p.Fields) == 0 {
+		// Special fields are stored alongside jsonPayload in the record; leave them alone.
+		lua.WriteString(`for k, v in pairs(record) do
+  if not string.find(k, "^logging%.googleapis%.com/") and not string.find(k, "^agent%.googleapis%.com/") then
+    if type(v) == "string" then
+      record[k] = redact(v)
+    elseif type(v) == "table" then
+      redact_all(v)
+    end
+  end
+end
+`)
+	}
+	for _, field := range p.Fields {
+		m, err := filter.NewMember(field)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse field %q: %w", field, err)
+		}
+		get, err := m.LuaAccessor(false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert field %q to Lua accessor: %w", field, err)
+		}
+		set, err := m.LuaAccessor(true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert field %q to Lua accessor: %w", field, err)
+		}
+		fmt.Fprintf(&lua, `local v = %s;
+if type(v) == "string" then
+%s(redact(v))
+end
+`, get, set)
+	}
+	lua.WriteString("return 2, timestamp, record\nend\n")
+	return fluentbit.LuaFilterComponents(tag, "process", lua.String()), nil
+}
+
+func (p LoggingProcessorRedactFields) Processors(ctx context.Context) ([]otel.Component, error) {
+	if err := p.ValidateBackend(BackendOTel); err != nil {
+		return nil, fmt.Errorf("redact_fields %w", err)
+	}
+	rules, err := p.rules()
+	if err != nil {
+		return nil, err
+	}
+	replacement := ottl.StringLiteral(p.mask())
+	var function, format string
+	if p.Method == "hash" {
+		// $$ escapes $ in the collector config; the replacement expands to the whole match.
+		replacement = ottl.StringLiteral("$$0")
+		function = "SHA256"
+		format = "sha256:%s"
+	}
+	var statements ottl.Statements
+	for _, r := range rules {
+		for _, field := range p.Fields {
+			m, err := filter.NewMember(field)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse field %q: %w", field, err)
+			}
+			a, err := m.OTTLAccessor()
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert field %q to OTTL accessor: %w", field, err)
+			}
+			statements = statements.Append(
+				a.ReplacePatternIf(r.regex, replacement, function, format, a.IsString()),
+			)
+		}
+	}
+	return []otel.Component{otel.Transform(
+		"log", "log",
+		statements,
+	)}, nil
+}
+
+func init() {
+	LoggingProcessorTypes.RegisterType(func() LoggingProcessor { return &LoggingProcessorRedactFields{} })
+}
+
+// validateRedactPattern checks that pattern is a valid regular expression that can be used by both logging backends.
+func validateRedactPattern(pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	if re.MatchString("") {
+		return fmt.Errorf("pattern must not match an empty string")
+	}
+	_, err = luaPattern(pattern)
+	return err
+}
+
+// luaPattern translates an RE2 regular expression into an equivalent Lua pattern.
+// Lua patterns do not support alternation or quantified groups, and only operate on bytes,
+// so only regular expressions that use quantifiers on single ASCII characters or character classes can be translated.
+func luaPattern(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", err
+	}
+	subs := []*syntax.Regexp{re}
+	if re.Op == syntax.OpConcat {
+		subs = re.Sub
+	}
+	var out strings.Builder
+	for i, sub := range subs {
+		switch sub.Op {
+		case syntax.OpBeginText:
+			if i == 0 {
+				out.WriteString("^")
+				continue
+			}
+		case syntax.OpEndText:
+			if i == len(subs)-1 {
+				out.WriteString("$")
+				continue
+			}
+		}
+		s, err := luaPatternExpr(sub)
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(s)
+	}
+	return out.String(), nil
+}
+
+func luaPatternExpr(re *syntax.Regexp) (string, error) {
+	switch re.Op {
+	case syntax.OpEmptyMatch:
+		return "", nil
+	case syntax.OpLiteral:
+		var out strings.Builder
+		for _, r := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && unicode.IsLetter(r) && r < utf8.RuneSelf {
+				fmt.Fprintf(&out, "[%c%c]", unicode.ToLower(r), unicode.ToUpper(r))
+			} else {
+				out.WriteString(luaPatternChar(r))
+			}
+		}
+		return out.String(), nil
+	case syntax.OpCapture:
+		return luaPatternExpr(re.Sub[0])
+	case syntax.OpConcat:
+		var out strings.Builder
+		for _, sub := range re.Sub {
+			s, err := luaPatternExpr(sub)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(s)
+		}
+		return out.String(), nil
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL, syntax.OpCharClass:
+		return luaPatternItem(re)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		item, err := luaPatternItem(re.Sub[0])
+		if err != nil {
+			return "", err
+		}
+		min, max := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			min, max = 0, -1
+		case syntax.OpPlus:
+			min, max = 1, -1
+		case syntax.OpQuest:
+			min, max = 0, 1
+		}
+		nonGreedy := re.Flags&syntax.NonGreedy != 0
+		if nonGreedy && max != -1 {
+			return "", fmt.Errorf("non-greedy %s is not supported", re)
+		}
+		switch {
+		case max != -1:
+			return strings.Repeat(item, min) + strings.Repeat(item+"?", max-min), nil
+		case nonGreedy:
+			return strings.Repeat(item, min) + item + "-", nil
+		case min > 0:
+			return strings.Repeat(item, min-1) + item + "+", nil
+		}
+		return item + "*", nil
+	}
+	switch re.Op {
+	case syntax.OpAlternate:
+		return "", fmt.Errorf("alternation %s is not supported", re)
+	case syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return "", fmt.Errorf("word boundaries are not supported")
+	case syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText:
+		return "", fmt.Errorf("%s is only supported at the start or end of the pattern", re)
+	}
+	return "", fmt.Errorf("%s is not supported", re)
+}
+
+// luaPatternItem translates a regular expression that matches a single ASCII character.
+func luaPatternItem(re *syntax.Regexp) (string, error) {
+	switch re.Op {
+	case syntax.OpAnyChar:
+		return ".", nil
+	case syntax.OpAnyCharNotNL:
+		return "[^\n]", nil
+	case syntax.OpLiteral:
+		if len(re.Rune) == 1 && re.Rune[0] < utf8.RuneSelf {
+			return luaPatternExpr(re)
+		}
+	case syntax.OpCharClass:
+		ranges := re.Rune
+		negate := false
+		if len(ranges) > 0 && ranges[len(ranges)-1] == unicode.MaxRune && ranges[len(ranges)-2] < utf8.RuneSelf {
+			// Classes that include all non-ASCII characters are generated for negated classes like [^"]; negate them back.
+			negate = true
+			var complement []rune
+			next := rune(0)
+			for i := 0; i < len(ranges); i += 2 {
+				if ranges[i] > next {
+					complement = append(complement, next, ranges[i]-1)
+				}
+				next = ranges[i+1] + 1
+			}
+			ranges = complement
+			if len(ranges) == 0 {
+				return ".", nil
+			}
+		}
+		var out strings.Builder
+		out.WriteString("[")
+		if negate {
+			out.WriteString("^")
+		}
+		for i := 0; i < len(ranges); i += 2 {
+			if ranges[i+1] >= utf8.RuneSelf {
+				return "", fmt.Errorf("non-ASCII character class %s is not supported", re)
+			}
+			out.WriteString(luaPatternRange(ranges[i], ranges[i+1]))
+		}
+		out.WriteString("]")
+		if out.Len() == 2 {
+			// An empty class can never match.
+			return "", fmt.Errorf("empty character class %s is not supported", re)
+		}
+		return out.String(), nil
+	}
+	return "", fmt.Errorf("repetition of %s is not supported", re)
+}
+
+// luaPatternChar returns a Lua pattern that matches r literally.
+func luaPatternChar(r rune) string {
+	switch {
+	case r == 0:
+		return "%z"
+	case r < utf8.RuneSelf && (unicode.IsPunct(r) || unicode.IsSymbol(r)):
+		return "%" + string(r)
+	}
+	return string(r)
+}
+
+// luaPatternRange returns the contents of a Lua set that matches characters from lo to hi.
+func luaPatternRange(lo, hi rune) string {
+	unsafe := func(r rune) bool {
+		return r == 0 || strings.ContainsRune(`]^%-`, r)
+	}
+	switch {
+	case lo == hi:
+		return luaPatternChar(lo)
+	case unsafe(lo):
+		return luaPatternChar(lo) + luaPatternRange(lo+1, hi)
+	case unsafe(hi):
+		return luaPatternRange(lo, hi-1) + luaPatternChar(hi)
+	}
+	return string(lo) + "-" + string(hi)
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confgenerator
+
+import "testing"
+
+func TestLuaPattern(t *testing.T) {
+	for _, test := range []struct {
+		regex string
+		want  string
+	}{
+		{`abc`, `abc`},
+		{`^a.b$`, "^a[^\n]b$"},
+		{`(?s)a.b`, `a.b`},
+		{`a+b*c?`, `a+b*c?`},
+		{`a*?b+?`, `a-bb-`},
+		{`x{3}`, `xxx`},
+		{`x{2,4}`, `xxx?x?`},
+		{`x{2,}`, `xx+`},
+		{`[0-9a-f]+`, `[0-9a-f]+`},
+		{`[^"]*`, `[^%"]*`},
+		{`[-.%\]^]`, `[%%%-%.%]%^]`},
+		{`a.b\.c`, "a[^\n]b%.c"},
+		{`(?i)id=`, `[iI][dD]%=`},
+		{`(ab)c`, `abc`},
+		{`\x00`, `%z`},
+		{`token=[A-Za-z0-9_-]{8}`, `token%=[%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z]`},
+	} {
+		t.Run(test.regex, func(t *testing.T) {
+			got, err := luaPattern(test.regex)
+			if err != nil {
+				t.Fatalf("luaPattern(%q) returned error: %v", test.regex, err)
+			}
+			if got != test.want {
+				t.Errorf("luaPattern(%q) = %q, want %q", test.regex, got, test.want)
+			}
+		})
+	}
+}
+
+func TestLuaPatternUnsupported(t *testing.T) {
+	for _, regex := range []string{
+		`a|bc`,
+		`(ab)+`,
+		`\bword\b`,
+		`a^b`,
+		`a$b`,
+		`é+`,
+		`[à-ü]`,
+		`a??`,
+		`x{2,4}?`,
+	} {
+		t.Run(regex, func(t *testing.T) {
+			if got, err := luaPattern(regex); err == nil {
+				t.Errorf("luaPattern(%q) = %q, want error", regex, got)
+			}
+		})
+	}
+}
+
+func TestRedactDetectorsTranslate(t *testing.T) {
+	for name := range redactDetectors {
+		p := LoggingProcessorRedactFields{Detectors: []string{name}}
+		if _, err := p.rules(); err != nil {
+			t.Errorf("detector %q: %v", name, err)
+		}
+	}
+}
+
+func TestRedactFieldsValidateBackend(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		p       LoggingProcessorRedactFields
+		wantErr bool
+	}{
+		{"fields", LoggingProcessorRedactFields{Fields: []string{"jsonPayload.message"}, Detectors: []string{"email"}}, false},
+		{"no fields", LoggingProcessorRedactFields{Detectors: []string{"email"}}, true},
+		// The OTel backend cannot check the Luhn checksum.
+		{"credit card", LoggingProcessorRedactFields{Fields: []string{"jsonPayload.message"}, Detectors: []string{"credit_card"}}, true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.p.ValidateBackend(BackendFluentBit); err != nil {
+				t.Errorf("ValidateBackend(BackendFluentBit) = %v, want nil", err)
+			}
+			if err := test.p.ValidateBackend(BackendOTel); (err != nil) != test.wantErr {
+				t.Errorf("ValidateBackend(BackendOTel) = %v, want error %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package ottl
 import (
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -67,6 +68,15 @@ func IntLiteral(v int) Value {
 	return valuef(`%d`, v)
 }
 
+// FloatLiteral always renders v with a decimal point so OTTL parses it as a double.
+func FloatLiteral(v float64) Value {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return RValue(s)
+}
+
 func False() Value {
 	return valuef("false")
 }
@@ -160,6 +170,14 @@ func (a LValue) IsString() Value {
 	return valuef(`IsString(%s)`, a)
 }
 
+func (a LValue) IsInt() Value {
+	return valuef(`IsInt(%s)`, a)
+}
+
+func (a LValue) IsDouble() Value {
+	return valuef(`IsDouble(%s)`, a)
+}
+
 func ToString(a Value) Value {
 	return valuef(`Concat([%s], "")`, a)
 }
@@ -186,10 +204,50 @@ func ParseSimplifiedXML(a Value) Value {
 	return valuef(`ParseSimplifiedXML(%s)`, a)
 }
 
+func ParseKeyValue(a Value, delimiter, pairDelimiter string) Value {
+	return valuef(`ParseKeyValue(%s, %q, %q)`, a, delimiter, pairDelimiter)
+}
+
+func ParseCSV(a Value, header, delimiter, mode string) Value {
+	return valuef(`ParseCSV(%s, %q, %q, %q, %q)`, a, header, delimiter, delimiter, mode)
+}
+
+// ConvertAttributesToElementsXML turns the attributes of every element in the XML string a into child elements.
+func ConvertAttributesToElementsXML(a Value) Value {
+	return valuef(`ConvertAttributesToElementsXML(%s)`, a)
+}
+
+// ConvertTextToElementsXML wraps text that is mixed with child elements in the XML string a into elements named elementName.
+func ConvertTextToElementsXML(a Value, elementName string) Value {
+	return valuef(`ConvertTextToElementsXML(%s, "/", %q)`, a, elementName)
+}
+
 func ExtractPatternsRubyRegex(a Value, pattern string, omitEmptyValues bool) Value {
 	return valuef(`ExtractPatternsRubyRegex(%s, %q, %v)`, a, pattern, omitEmptyValues)
 }
 
+// FNV returns the 64-bit FNV-1a hash of a as a signed integer.
+func FNV(a Value) Value {
+	return valuef(`FNV(%s)`, a)
+}
+
+// UUID returns a random version 4 UUID, which is generated again every time it is evaluated.
+func UUID() Value {
+	return valuef(`UUID()`)
+}
+
+func Subtract(a, b Value) Value {
+	return valuef(`(%s - %s)`, a, b)
+}
+
+func Multiply(a, b Value) Value {
+	return valuef(`(%s * %s)`, a, b)
+}
+
+func Divide(a, b Value) Value {
+	return valuef(`(%s / %s)`, a, b)
+}
+
 func Concat(values []Value, delimiter string) Value {
 	stringValues := []string{}
 	for _, v := range values {
@@ -226,6 +284,11 @@ func Equals(a, b Value) Value {
 	return valuef(`%s == %s`, a, b)
 }
 
+// Compare applies one of the OTTL comparison operators (<, <=, >, >=, ==, !=) to a and b.
+func Compare(a Value, operator string, b Value) Value {
+	return valuef(`%s %s %s`, a, operator, b)
+}
+
 func Not(a Value) Value {
 	return valuef(`(not %s)`, a)
 }
@@ -290,6 +353,25 @@ func (a LValue) SetToYesNoBoolean(b Value) Statements {
 	return out
 }
 
+// ReplacePatternIf replaces every match of regex in a with replacement, if condition is true.
+// If function is not empty, the named hash function is applied to each expanded replacement, and the result is formatted with format.
+func (a LValue) ReplacePatternIf(regex string, replacement Value, function, format string, condition Value) Statements {
+	var hashStr string
+	if function != "" {
+		hashStr = fmt.Sprintf(", %s, %q", function, format)
+	}
+	return statementsf(`replace_pattern(%s, %q, %s%s) where %s`, a, regex, replacement, hashStr, condition)
+}
+
+// ReplaceAllPatternsIf is like ReplacePatternIf, but applies to every value (mode "value") or key (mode "key") of the map a.
+func (a LValue) ReplaceAllPatternsIf(mode, regex string, replacement Value, function, format string, condition Value) Statements {
+	var hashStr string
+	if function != "" {
+		hashStr = fmt.Sprintf(", %s, %q", function, format)
+	}
+	return statementsf(`replace_all_patterns(%s, %q, %q, %s%s) where %s`, a, mode, regex, replacement, hashStr, condition)
+}
+
 // Delete removes a (potentially nested) key from its parent maps, if that key exists.
 func (a LValue) Delete() Statements {
 	parent := a[:len(a)-1]
@@ -21,6 +21,7 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -34,7 +35,10 @@ import (
 	"github.com/prometheus/common/model"
 	promconfig "github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/discovery"
+	"github.com/prometheus/prometheus/discovery/file"
+	"github.com/prometheus/prometheus/discovery/http"
 	_ "github.com/prometheus/prometheus/discovery/install" // init() of this package registers service discovery impl.
+	"github.com/prometheus/prometheus/model/relabel"
 )
 
 const minScrapeInterval = model.Duration(10 * time.Second)
@@ -67,31 +71,13 @@ func (r PrometheusMetrics) Pipelines(ctx context.Context) ([]otel.ReceiverPipeli
 		log.Printf("can't get resource metadata: %v", err)
 		return nil, nil
 	}
+	var resourceMetadataMap map[string]string
 	if resource != nil {
 		// Get the resource metadata for the instance we're running on.
-		resourceMetadataMap := resource.PrometheusStyleMetadata()
-		// Add the resource metadata to the prometheus config.
-		for i := range r.PromConfig.ScrapeConfigs {
-			// Iterate over the static configs.
-			for j := range r.PromConfig.ScrapeConfigs[i].ServiceDiscoveryConfigs {
-				staticConfigs := r.PromConfig.ScrapeConfigs[i].ServiceDiscoveryConfigs[j].(discovery.StaticConfig)
-				for k := range staticConfigs {
-					labels := staticConfigs[k].Labels
-					if labels == nil {
-						labels = model.LabelSet{}
-					}
-					for k, v := range resourceMetadataMap {
-						// If there are conflicts, the resource metadata should take precedence.
-						labels[model.LabelName(k)] = model.LabelValue(v)
-					}
-
-					staticConfigs[k].Labels = labels
-				}
-			}
-		}
+		resourceMetadataMap = resource.PrometheusStyleMetadata()
 	}
 	return []otel.ReceiverPipeline{ConvertPrometheusExporterToOtlpExporter(otel.ReceiverPipeline{
-		Receiver: prometheusToOtelComponent(r),
+		Receiver: prometheusToOtelComponent(r, resourceMetadataMap),
 		Processors: map[string][]otel.Component{
 			// Expect metrics, without any additional processing.
 			"metrics": []otel.Component{otel.GroupByGMPAttrs_OTTL()},
@@ -106,17 +92,35 @@ func (r PrometheusMetrics) Pipelines(ctx context.Context) ([]otel.ReceiverPipeli
 }
 
 // Generate otel components for the prometheus config used. It is the same config except
-// we need to escape the $ characters in the regexes.
+// we add the resource metadata to every target and we need to escape the $ characters in the regexes.
 //
 // Note: We copy over the prometheus scrape configs and create new ones so calls to `Pipelines()`
 // will return the same result everytime and not change the original prometheus config.
-func prometheusToOtelComponent(m PrometheusMetrics) otel.Component {
+func prometheusToOtelComponent(m PrometheusMetrics, resourceMetadata map[string]string) otel.Component {
 	copyPromConfig, err := deepCopy(m.PromConfig)
 	if err != nil {
 		// This should never happen since we already validated the prometheus config.
 		panic(fmt.Errorf("failed to deep copy prometheus config: %w", err))
 	}
 
+	// Add the resource metadata to the targets with relabeling rules, so that it is also applied to
+	// targets from dynamic service discovery. The rules come first so that the user's relabel_configs
+	// can use the __meta_gce_* labels.
+	var resourceRelabelConfigs []*relabel.Config
+	for _, k := range GetSortedKeys(resourceMetadata) {
+		resourceRelabelConfigs = append(resourceRelabelConfigs, &relabel.Config{
+			Action:    relabel.Replace,
+			Separator: relabel.DefaultRelabelConfig.Separator,
+			Regex:     relabel.DefaultRelabelConfig.Regex,
+			// The metadata values already have their $ characters doubled, which also escapes them in the replacement.
+			Replacement: resourceMetadata[k],
+			TargetLabel: k,
+		})
+	}
+	for _, sc := range copyPromConfig.ScrapeConfigs {
+		sc.RelabelConfigs = append(slices.Clone(resourceRelabelConfigs), sc.RelabelConfigs...)
+	}
+
 	// Escape the $ characters in the regexes.
 	for i := range copyPromConfig.ScrapeConfigs {
 		for j := range copyPromConfig.ScrapeConfigs[i].RelabelConfigs {
@@ -264,6 +268,11 @@ func validatePrometheus(promConfig promconfig.Config) (string, error) {
 		for _, c := range sc.ServiceDiscoveryConfigs {
 			switch c := c.(type) {
 			case discovery.StaticConfig:
+			case *file.SDConfig:
+			case *http.SDConfig:
+				if err := checkTLSConfig(c.HTTPClientConfig.TLSConfig); err != nil {
+					return "http_sd_configs.tls_config", err
+				}
 			default:
 				return fmt.Sprintf("%T", c), fmt.Errorf("unsupported service discovery config %T", c)
 			}
@@ -290,12 +299,17 @@ func (r PrometheusMetrics) ExtractFeatures() ([]CustomFeature, bool, error) {
 	for i := range r.PromConfig.ScrapeConfigs {
 		sc := r.PromConfig.ScrapeConfigs[i]
 
-		// Since we only support static_configs, there is only over one service discovery config.
 		scTargetGroups := 0
+		fileSDConfigs := 0
+		httpSDConfigs := 0
 		for _, c := range sc.ServiceDiscoveryConfigs {
 			switch c := c.(type) {
 			case discovery.StaticConfig:
 				scTargetGroups += len(c)
+			case *file.SDConfig:
+				fileSDConfigs++
+			case *http.SDConfig:
+				httpSDConfigs++
 			default:
 			}
 		}
@@ -315,6 +329,8 @@ func (r PrometheusMetrics) ExtractFeatures() ([]CustomFeature, bool, error) {
 			{"relabel_configs", fmt.Sprintf("%d", len(sc.RelabelConfigs))},
 			{"metric_relabel_configs", fmt.Sprintf("%d", len(sc.MetricRelabelConfigs))},
 			{"static_config_target_groups", fmt.Sprintf("%d", scTargetGroups)},
+			{"file_sd_configs", fmt.Sprintf("%d", fileSDConfigs)},
+			{"http_sd_configs", fmt.Sprintf("%d", httpSDConfigs)},
 		}
 
 		for _, metric := range trackingMetrics {
@@ -349,5 +365,7 @@ func (r PrometheusMetrics) ListAllFeatures() ([]string, bool) {
 		"config.[].scrape_configs.relabel_configs",
 		"config.[].scrape_configs.metric_relabel_configs",
 		"config.[].scrape_configs.static_config_target_groups",
+		"config.[].scrape_configs.file_sd_configs",
+		"config.[].scrape_configs.http_sd_configs",
 	}, true
 }
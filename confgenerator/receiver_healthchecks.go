@@ -0,0 +1,66 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confgenerator
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/ops-agent/internal/healthchecks"
+)
+
+// ReceiverHealthChecks returns the health checks of the network ports, endpoints
+// and log files used by the receivers of uc.
+func (uc *UnifiedConfig) ReceiverHealthChecks(ctx context.Context) (healthchecks.HealthCheckRegistry, error) {
+	listenAddresses, err := uc.ReceiverListenAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	receiversCheck := healthchecks.ReceiversCheck{
+		ListenAddresses: map[string]healthchecks.ListenAddress{},
+	}
+	for rID, address := range listenAddresses {
+		receiversCheck.ListenAddresses[rID] = healthchecks.ListenAddress(address)
+	}
+	if receiversCheck.TargetAddresses, err = uc.ReceiverTargetAddresses(ctx); err != nil {
+		return nil, err
+	}
+	filesReceivers, err := uc.LoggingFilesReceivers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	logFilesCheck := healthchecks.LogFilesCheck{
+		IncludePaths: map[string][]string{},
+		ExcludePaths: map[string][]string{},
+	}
+	for rID, r := range filesReceivers {
+		logFilesCheck.IncludePaths[rID] = r.IncludePaths
+		logFilesCheck.ExcludePaths[rID] = r.ExcludePaths
+	}
+	return healthchecks.HealthCheckRegistry{receiversCheck, logFilesCheck}, nil
+}
+
+// HealthCheckRegistry returns the default health checks followed by the
+// receiver health checks of the merged config at userConfPath.
+func HealthCheckRegistry(ctx context.Context, userConfPath string) (healthchecks.HealthCheckRegistry, error) {
+	uc, err := MergeConfFiles(ctx, userConfPath)
+	if err != nil {
+		return nil, err
+	}
+	receiverChecks, err := uc.ReceiverHealthChecks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(healthchecks.HealthCheckRegistryFactory(), receiverChecks...), nil
+}
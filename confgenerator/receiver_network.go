@@ -0,0 +1,101 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confgenerator
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// A ListenAddress is the transport protocol, host and port that a receiver listens on.
+type ListenAddress struct {
+	// Protocol is "tcp" or "udp".
+	Protocol string
+	// Host is the IP address or hostname to listen on. An unspecified address listens on all of them.
+	Host string
+	Port uint16
+}
+
+// NetworkListenerReceiver is implemented by receivers that listen on a port of the host.
+type NetworkListenerReceiver interface {
+	GetListenAddress() ListenAddress
+}
+
+// NetworkClientReceiver is implemented by receivers that connect to an application over the network.
+type NetworkClientReceiver interface {
+	// GetTargetAddress returns the host:port that the receiver connects to,
+	// or "" if the receiver does not connect over TCP.
+	GetTargetAddress() string
+}
+
+// TargetAddress returns the host:port of a receiver endpoint, which may be a
+// host:port, a URL, or the path of a Unix socket. It returns "" for Unix sockets.
+func TargetAddress(endpoint string) string {
+	if endpoint == "" || strings.HasPrefix(endpoint, "/") {
+		return ""
+	}
+	u, err := url.Parse(endpoint)
+	if err == nil && u.Scheme == "unix" {
+		return ""
+	}
+	if err != nil || u.Host == "" {
+		// Not a URL, e.g. "127.0.0.1:3306" or "localhost:6379".
+		return endpoint
+	}
+	switch {
+	case u.Port() != "":
+		return u.Host
+	case u.Scheme == "https":
+		return net.JoinHostPort(u.Hostname(), "443")
+	default:
+		return net.JoinHostPort(u.Hostname(), "80")
+	}
+}
+
+// ReceiverListenAddresses returns a map of receiver IDs to listen addresses for all
+// receivers used in a pipeline that listen on a port of the host.
+func (uc *UnifiedConfig) ReceiverListenAddresses(ctx context.Context) (map[string]ListenAddress, error) {
+	pipelines, err := uc.Pipelines(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addresses := map[string]ListenAddress{}
+	for _, p := range pipelines {
+		if r, ok := p.Receiver.(NetworkListenerReceiver); ok {
+			addresses[p.RID] = r.GetListenAddress()
+		}
+	}
+	return addresses, nil
+}
+
+// ReceiverTargetAddresses returns a map of receiver IDs to the host:port that
+// they connect to for all receivers used in a pipeline that connect over TCP.
+func (uc *UnifiedConfig) ReceiverTargetAddresses(ctx context.Context) (map[string]string, error) {
+	pipelines, err := uc.Pipelines(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addresses := map[string]string{}
+	for _, p := range pipelines {
+		if r, ok := p.Receiver.(NetworkClientReceiver); ok {
+			if address := r.GetTargetAddress(); address != "" {
+				addresses[p.RID] = address
+			}
+		}
+	}
+	return addresses, nil
+}
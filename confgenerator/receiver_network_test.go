@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confgenerator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
+	"github.com/GoogleCloudPlatform/ops-agent/internal/platform"
+	"github.com/shirou/gopsutil/host"
+	"gotest.tools/v3/assert"
+)
+
+func TestTargetAddress(t *testing.T) {
+	for _, tc := range []struct {
+		endpoint string
+		want     string
+	}{
+		{"", ""},
+		{"localhost:6379", "localhost:6379"},
+		{"127.0.0.1:3306", "127.0.0.1:3306"},
+		{"/var/run/mysqld/mysqld.sock", ""},
+		{"unix:///var/run/docker.sock", ""},
+		{"http://127.0.0.1/status", "127.0.0.1:80"},
+		{"https://localhost/status", "localhost:443"},
+		{"http://localhost:5984", "localhost:5984"},
+	} {
+		if got := confgenerator.TargetAddress(tc.endpoint); got != tc.want {
+			t.Errorf("TargetAddress(%q) = %q, want %q", tc.endpoint, got, tc.want)
+		}
+	}
+}
+
+const receiverNetworkConfig = `
+logging:
+  receivers:
+    syslog_udp:
+      type: syslog
+      transport_protocol: udp
+      listen_host: 0.0.0.0
+      listen_port: 5140
+    tcp:
+      type: tcp
+      format: json
+    forward:
+      type: fluent_forward
+      listen_host: 10.0.0.1
+      listen_port: 24225
+    unused:
+      type: tcp
+      format: json
+      listen_port: 5171
+  service:
+    pipelines:
+      network:
+        receivers: [syslog_udp, tcp, forward]
+combined:
+  receivers:
+    otlp:
+      type: otlp
+      grpc_endpoint: 127.0.0.1:4318
+metrics:
+  receivers:
+    redis:
+      type: redis
+    nginx:
+      type: nginx
+      stub_status_url: http://127.0.0.1/status
+    mysql:
+      type: mysql
+      endpoint: /var/run/mysqld/mysqld.sock
+  service:
+    pipelines:
+      apps:
+        receivers: [redis, nginx, mysql]
+traces:
+  service:
+    pipelines:
+      otlp:
+        receivers: [otlp]
+`
+
+func receiverNetworkUnifiedConfig(t *testing.T) (context.Context, *confgenerator.UnifiedConfig) {
+	t.Helper()
+	ctx := platform.Platform{
+		Type:     platform.Linux,
+		HostInfo: &host.InfoStat{OS: "linux"},
+	}.TestContext(context.Background())
+	uc, err := confgenerator.UnmarshalYamlToUnifiedConfig(ctx, []byte(receiverNetworkConfig))
+	assert.NilError(t, err)
+	return ctx, uc
+}
+
+func TestReceiverListenAddresses(t *testing.T) {
+	ctx, uc := receiverNetworkUnifiedConfig(t)
+	got, err := uc.ReceiverListenAddresses(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, got, map[string]confgenerator.ListenAddress{
+		"syslog_udp": {Protocol: "udp", Host: "0.0.0.0", Port: 5140},
+		"tcp":        {Protocol: "tcp", Host: "127.0.0.1", Port: 5170},
+		"forward":    {Protocol: "tcp", Host: "10.0.0.1", Port: 24225},
+		"otlp":       {Protocol: "tcp", Host: "127.0.0.1", Port: 4318},
+	})
+}
+
+func TestReceiverTargetAddresses(t *testing.T) {
+	ctx, uc := receiverNetworkUnifiedConfig(t)
+	got, err := uc.ReceiverTargetAddresses(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, got, map[string]string{
+		"redis": "localhost:6379",
+		"nginx": "127.0.0.1:80",
+	})
+}
@@ -0,0 +1,28 @@
+
+function process(tag, timestamp, record)
+local __field_0 = (function()
+return record["password"]
+end)();
+local omit0 = (not (function(needle)
+local function search(t)
+for k, v in pairs(t) do
+if type(k) ~= "string" or string.sub(k, 1, 8) ~= "__match_" then
+if type(v) == "string" and string.find(string.lower(v), needle, 1, true) ~= nil then
+return true
+end
+if type(v) == "table" and search(v) then
+return true
+end
+end
+end
+return false
+end
+return search(record)
+end)(string.lower("secret")));
+local v = __field_0;
+if omit0 then v = nil end;
+(function(value)
+record["password"] = value
+end)(v)
+return 2, timestamp, record
+end
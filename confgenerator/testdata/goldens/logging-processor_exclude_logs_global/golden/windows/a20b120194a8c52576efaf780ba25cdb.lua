@@ -0,0 +1,24 @@
+
+function process(tag, timestamp, record)
+local match = ((function(needle)
+local function search(t)
+for k, v in pairs(t) do
+if type(k) ~= "string" or string.sub(k, 1, 8) ~= "__match_" then
+if type(v) == "string" and string.find(string.lower(v), needle, 1, true) ~= nil then
+return true
+end
+if type(v) == "table" and search(v) then
+return true
+end
+end
+end
+return false
+end
+return search(record)
+end)(string.lower("connection refused")));
+
+  if match then
+    return -1, 0, 0
+  end
+  return 2, 0, record
+end
\ No newline at end of file
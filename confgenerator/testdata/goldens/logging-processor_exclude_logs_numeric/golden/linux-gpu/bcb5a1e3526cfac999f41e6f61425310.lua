@@ -0,0 +1,11 @@
+
+function process(tag, timestamp, record)
+local match = ((function(v) if v == nil then return false end return (function(n) return n ~= nil and n < -3 end)((type(v) == "number" and v) or (type(v) == "string" and (string.match(v, "^[+-]?%d+%.?%d*$") or string.match(v, "^[+-]?%.%d+$")) and tonumber(v)) or nil) end)((function()
+return record["delta"]
+end)()));
+
+  if match then
+    return -1, 0, 0
+  end
+  return 2, 0, record
+end
\ No newline at end of file
@@ -0,0 +1,15 @@
+
+function process(tag, timestamp, record)
+local __field_0 = (function()
+return record["retries"]
+end)();
+local omit0 = (function(v) if v == nil then return false end return (function(n) return n ~= nil and n < 1 end)((type(v) == "number" and v) or (type(v) == "string" and (string.match(v, "^[+-]?%d+%.?%d*$") or string.match(v, "^[+-]?%.%d+$")) and tonumber(v)) or nil) end)((function()
+return record["retries"]
+end)());
+local v = __field_0;
+if omit0 then v = nil end;
+(function(value)
+record["retries"] = value
+end)(v)
+return 2, timestamp, record
+end
@@ -0,0 +1,133 @@
+
+local field = "row"
+local delimiter = "\9"
+local quote = "'"
+local columns = {"host", "status"}
+local types = {}
+
+local json_escapes = {
+  ['"'] = '\\"', ['\\'] = '\\\\', ['\b'] = '\\b', ['\f'] = '\\f',
+  ['\n'] = '\\n', ['\r'] = '\\r', ['\t'] = '\\t',
+}
+
+local function json_string(s)
+  return '"' .. s:gsub('[%c"\\]', function(c)
+    return json_escapes[c] or string.format("\\u%04x", c:byte())
+  end) .. '"'
+end
+
+-- typecast returns v converted to type t as a JSON literal, or nil if v cannot be converted.
+local function typecast(v, t)
+  if t == "integer" then
+    if v:match("^[-+]?%d+$") then
+      return string.format("%d", tonumber(v))
+    end
+  elseif t == "float" then
+    local n = tonumber(v)
+    if n ~= nil and n == n and n ~= math.huge and n ~= -math.huge then
+      local s = string.format("%.17g", n)
+      if not s:match("[.e]") then
+        s = s .. ".0"
+      end
+      return s
+    end
+  elseif t == "hex" then
+    local sign, digits = v:match("^([-+]?)(%x+)$")
+    if digits ~= nil then
+      local n = tonumber(digits, 16)
+      if sign == "-" then
+        n = -n
+      end
+      return string.format("%d", n)
+    end
+  elseif t == "bool" then
+    if v == "true" or v == "false" then
+      return v
+    end
+  elseif t == "string" then
+    return json_string(v)
+  end
+  return nil
+end
+
+-- json_object encodes values[k] for each k in keys as a JSON object, converting them according to types.
+local function json_object(keys, values)
+  local out = {}
+  for _, k in ipairs(keys) do
+    local v = values[k]
+    if types[k] ~= nil then
+      v = typecast(v, types[k]) or json_string(v)
+    else
+      v = json_string(v)
+    end
+    table.insert(out, json_string(k) .. ":" .. v)
+  end
+  return "{" .. table.concat(out, ",") .. "}"
+end
+
+local function parse_row(s)
+  local fields = {}
+  local i = 1
+  while true do
+    if s:sub(i, i) == quote then
+      local buf = {}
+      i = i + 1
+      while true do
+        local j = string.find(s, quote, i, true)
+        if j == nil then
+          return nil
+        end
+        table.insert(buf, s:sub(i, j - 1))
+        i = j + 1
+        if s:sub(i, i) ~= quote then
+          break
+        end
+        table.insert(buf, quote)
+        i = i + 1
+      end
+      table.insert(fields, table.concat(buf))
+      if i > #s then
+        return fields
+      end
+      if s:sub(i, i + #delimiter - 1) ~= delimiter then
+        return nil
+      end
+      i = i + #delimiter
+    else
+      local j = string.find(s, delimiter, i, true)
+      local f = s:sub(i, (j or #s + 1) - 1)
+      if string.find(f, quote, 1, true) then
+        return nil
+      end
+      table.insert(fields, f)
+      if j == nil then
+        return fields
+      end
+      i = j + #delimiter
+    end
+  end
+end
+
+function parse_csv(tag, timestamp, record)
+  local source = record[field]
+  if type(source) ~= "string" or source == "" then
+    return 0, 0, 0
+  end
+  local fields = parse_row(source)
+  if fields == nil or #fields ~= #columns then
+    return 0, 0, 0
+  end
+  local values = {}
+  local header = true
+  for i, k in ipairs(columns) do
+    values[k] = fields[i]
+    if fields[i] ~= k then
+      header = false
+    end
+  end
+  if header then
+    return -1, 0, 0
+  end
+  record[field] = json_object(columns, values)
+  return 2, 0, record
+end
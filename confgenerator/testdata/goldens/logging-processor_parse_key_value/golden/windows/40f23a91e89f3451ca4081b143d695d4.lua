@@ -0,0 +1,129 @@
+
+local field = "details"
+local field_delimiter = ":"
+local pair_delimiter = ";"
+local quotes = {["\""] = true, ["'"] = true}
+local types = {}
+
+local json_escapes = {
+  ['"'] = '\\"', ['\\'] = '\\\\', ['\b'] = '\\b', ['\f'] = '\\f',
+  ['\n'] = '\\n', ['\r'] = '\\r', ['\t'] = '\\t',
+}
+
+local function json_string(s)
+  return '"' .. s:gsub('[%c"\\]', function(c)
+    return json_escapes[c] or string.format("\\u%04x", c:byte())
+  end) .. '"'
+end
+
+-- typecast returns v converted to type t as a JSON literal, or nil if v cannot be converted.
+local function typecast(v, t)
+  if t == "integer" then
+    if v:match("^[-+]?%d+$") then
+      return string.format("%d", tonumber(v))
+    end
+  elseif t == "float" then
+    local n = tonumber(v)
+    if n ~= nil and n == n and n ~= math.huge and n ~= -math.huge then
+      local s = string.format("%.17g", n)
+      if not s:match("[.e]") then
+        s = s .. ".0"
+      end
+      return s
+    end
+  elseif t == "hex" then
+    local sign, digits = v:match("^([-+]?)(%x+)$")
+    if digits ~= nil then
+      local n = tonumber(digits, 16)
+      if sign == "-" then
+        n = -n
+      end
+      return string.format("%d", n)
+    end
+  elseif t == "bool" then
+    if v == "true" or v == "false" then
+      return v
+    end
+  elseif t == "string" then
+    return json_string(v)
+  end
+  return nil
+end
+
+-- json_object encodes values[k] for each k in keys as a JSON object, converting them according to types.
+local function json_object(keys, values)
+  local out = {}
+  for _, k in ipairs(keys) do
+    local v = values[k]
+    if types[k] ~= nil then
+      v = typecast(v, types[k]) or json_string(v)
+    else
+      v = json_string(v)
+    end
+    table.insert(out, json_string(k) .. ":" .. v)
+  end
+  return "{" .. table.concat(out, ",") .. "}"
+end
+
+local function split(s, delimiter)
+  local result = {}
+  local current = {}
+  local quote = nil
+  local i = 1
+  while i <= #s do
+    local c = s:sub(i, i)
+    if quote == nil and s:sub(i, i + #delimiter - 1) == delimiter then
+      if #current > 0 then
+        table.insert(result, table.concat(current))
+        current = {}
+      end
+      i = i + #delimiter
+    else
+      if quote == nil and quotes[c] then
+        quote = c
+      elseif c == quote then
+        quote = nil
+      else
+        table.insert(current, c)
+      end
+      i = i + 1
+    end
+  end
+  if quote ~= nil then
+    return nil
+  end
+  if #current > 0 then
+    table.insert(result, table.concat(current))
+  end
+  return result
+end
+
+local function trim(s)
+  return s:match("^%s*(.-)%s*$")
+end
+
+function parse_key_value(tag, timestamp, record)
+  local source = record[field]
+  if type(source) ~= "string" or source == "" then
+    return 0, 0, 0
+  end
+  local kvs = split(source, pair_delimiter)
+  if kvs == nil then
+    return 0, 0, 0
+  end
+  local keys = {}
+  local values = {}
+  for _, pair in ipairs(kvs) do
+    local i = string.find(pair, field_delimiter, 1, true)
+    if i == nil then
+      return 0, 0, 0
+    end
+    local k = trim(pair:sub(1, i - 1))
+    if values[k] == nil then
+      table.insert(keys, k)
+    end
+    values[k] = trim(pair:sub(i + #field_delimiter))
+  end
+  record[field] = json_object(keys, values)
+  return 2, 0, record
+end
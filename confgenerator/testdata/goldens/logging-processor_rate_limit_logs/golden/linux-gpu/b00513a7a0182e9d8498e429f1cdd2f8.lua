@@ -0,0 +1,47 @@
+
+local buckets = {}
+local last_summary = os.time()
+
+function process(tag, timestamp, record)
+  local now = os.time()
+  local k = ""
+  local b = buckets[k]
+  if b == nil then
+    b = {tokens = 100, last = now, dropped = 0}
+    buckets[k] = b
+  end
+  b.tokens = math.min(100, b.tokens + (now - b.last) * 100)
+  b.last = now
+  local keep = b.tokens >= 1
+  if keep then
+    b.tokens = b.tokens - 1
+  else
+    b.dropped = b.dropped + 1
+  end
+  local records = {}
+  if now - last_summary >= 60 then
+    last_summary = now
+    for k, b in pairs(buckets) do
+      if b.dropped > 0 then
+        local summary = {}
+        summary["message"] = string.format("Dropped %d log entries that exceeded the rate limit of 100 per second", b.dropped)
+        summary["dropped_count"] = b.dropped
+        summary["logging.googleapis.com/severity"] = "WARNING"
+        table.insert(records, summary)
+        b.dropped = 0
+      elseif now - b.last >= 60 then
+        buckets[k] = nil
+      end
+    end
+  end
+  if #records == 0 then
+    if keep then
+      return 0, 0, 0
+    end
+    return -1, 0, 0
+  end
+  if keep then
+    table.insert(records, record)
+  end
+  return 2, 0, records
+end
\ No newline at end of file
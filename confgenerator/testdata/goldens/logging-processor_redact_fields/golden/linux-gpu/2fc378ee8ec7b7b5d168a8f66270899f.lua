@@ -0,0 +1,116 @@
+
+local function valid_ipv6(s)
+  local head, tail = s, nil
+  local i = string.find(s, "::", 1, true)
+  if i then
+    head, tail = string.sub(s, 1, i - 1), string.sub(s, i + 2)
+    if string.find(tail, "::", 1, true) then
+      return false
+    end
+  end
+  local n = 0
+  for _, part in ipairs({head, tail}) do
+    if part ~= "" then
+      for g in string.gmatch(part .. ":", "(.-):") do
+        if #g < 1 or #g > 4 then
+          return false
+        end
+        n = n + 1
+      end
+    end
+  end
+  if i then
+    return n > 0 and n <= 7
+  end
+  return n == 8
+end
+
+local function valid_luhn(digits)
+  local sum = 0
+  for i = #digits, 1, -1 do
+    local d = tonumber(string.sub(digits, i, i))
+    if (#digits - i) % 2 == 1 then
+      d = d * 2
+      if d > 9 then
+        d = d - 9
+      end
+    end
+    sum = sum + d
+  end
+  return sum % 10 == 0
+end
+
+-- Card numbers are made of whole digit groups separated by single spaces or hyphens,
+-- with 13 to 19 digits in total and a valid Luhn checksum. The longest one that starts
+-- at each group is redacted.
+local function redact_credit_cards(s, replace)
+  local groups = {}
+  for first, last in string.gmatch(s, "()%d+()") do
+    table.insert(groups, {first, last - 1})
+  end
+  local out, pos, i = {}, 1, 1
+  while i <= #groups do
+    local digits, found = "", nil
+    for j = i, #groups do
+      if j > i and groups[j][1] ~= groups[j - 1][2] + 2 then
+        break
+      end
+      digits = digits .. string.sub(s, groups[j][1], groups[j][2])
+      if #digits > 19 then
+        break
+      end
+      if #digits >= 13 and valid_luhn(digits) then
+        found = j
+      end
+    end
+    if found then
+      table.insert(out, string.sub(s, pos, groups[i][1] - 1))
+      table.insert(out, replace(string.sub(s, groups[i][1], groups[found][2])))
+      pos = groups[found][2] + 1
+      i = found + 1
+    else
+      i = i + 1
+    end
+  end
+  table.insert(out, string.sub(s, pos))
+  return table.concat(out)
+end
+
+local function replace(m)
+  return "[REDACTED]"
+end
+
+local function redact(s)
+  s = string.gsub(s, "[%%%+%-%.0-9A-Z%_a-z]+%@[%-%.0-9A-Za-z]+%.[A-Za-z][A-Za-z]+", replace)
+  s = string.gsub(s, "[0-9][0-9]?[0-9]?%.[0-9][0-9]?[0-9]?%.[0-9][0-9]?[0-9]?%.[0-9][0-9]?[0-9]?", replace)
+  s = string.gsub(s, "%f[%w:][%x:]+%f[^%w:]", function(m) if valid_ipv6(m) then return replace(m) end end)
+  s = string.gsub(s, "%f[%w]%d[%d %-]*%d%f[^%w]", function(m) return redact_credit_cards(m, replace) end)
+  s = string.gsub(s, "eyJ[%-0-9A-Z%_a-z]+%.[%-0-9A-Z%_a-z]+%.[%-0-9A-Z%_a-z]+", replace)
+  s = string.gsub(s, "A[KS]IA[0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z]", replace)
+  s = string.gsub(s, "AIza[%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z]", replace)
+  s = string.gsub(s, "[pP][aA][sS][sS][wW][oO][rR][dD]%=[^ ]+", replace)
+  return s
+end
+
+local function redact_all(t)
+  for k, v in pairs(t) do
+    if type(v) == "string" then
+      t[k] = redact(v)
+    elseif type(v) == "table" then
+      redact_all(v)
+    end
+  end
+end
+
+function process(tag, timestamp, record)
+for k, v in pairs(record) do
+  if not string.find(k, "^logging%.googleapis%.com/") and not string.find(k, "^agent%.googleapis%.com/") then
+    if type(v) == "string" then
+      record[k] = redact(v)
+    elseif type(v) == "table" then
+      redact_all(v)
+    end
+  end
+end
+return 2, timestamp, record
+end
@@ -0,0 +1,29 @@
+
+local function replace(m)
+  return "token=***"
+end
+
+local function redact(s)
+  s = string.gsub(s, "token%=[%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z][%-0-9A-Z%_a-z]+", replace)
+  return s
+end
+
+function process(tag, timestamp, record)
+local v = (function()
+if record["logging.googleapis.com/httpRequest"] == nil
+then
+return nil
+end
+return record["logging.googleapis.com/httpRequest"]["requestUrl"]
+end)();
+if type(v) == "string" then
+(function(value)
+if record["logging.googleapis.com/httpRequest"] == nil
+then
+record["logging.googleapis.com/httpRequest"] = {}
+end
+record["logging.googleapis.com/httpRequest"]["requestUrl"] = value
+end)(redact(v))
+end
+return 2, timestamp, record
+end
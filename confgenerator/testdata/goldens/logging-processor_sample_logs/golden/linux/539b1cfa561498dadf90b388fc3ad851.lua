@@ -0,0 +1,39 @@
+
+math.randomseed(os.time())
+
+local function sample_bucket(s)
+  local h = 14695981039346656037ULL
+  for i = 1, #s do
+    h = bit.bxor(h, s:byte(i)) * 1099511628211ULL
+  end
+  if h >= 0x8000000000000000ULL then
+    h = -h
+  end
+  return tonumber(h % 10000ULL)
+end
+
+function process(tag, timestamp, record)
+local match = ((function(v) if v == nil then return false end return (function(n) return n ~= nil and n < 400 end)((type(v) == "number" and v) or (type(v) == "string" and (string.match(v, "^[+-]?%d+%.?%d*$") or string.match(v, "^[+-]?%.%d+$")) and tonumber(v)) or nil) end)((function()
+if record["logging.googleapis.com/httpRequest"] == nil
+then
+return nil
+end
+return record["logging.googleapis.com/httpRequest"]["status"]
+end)()));
+
+  if match then
+    local v = (function()
+return record["logging.googleapis.com/trace"]
+end)()
+    local bucket
+    if (type(v) == "string" and v ~= "") or type(v) == "number" then
+      bucket = sample_bucket(tostring(v))
+    else
+      bucket = math.random(0, 9999)
+    end
+    if bucket >= 2500 then
+      return -1, 0, 0
+    end
+  end
+  return 2, 0, record
+end
\ No newline at end of file
@@ -0,0 +1,188 @@
+
+local severities = {"EMERGENCY", "ALERT", "CRITICAL", "ERROR", "WARNING", "NOTICE", "INFO", "DEBUG"}
+
+local function days_from_civil(y, m, d)
+  if m <= 2 then
+    y = y - 1
+  end
+  local era = math.floor(y / 400)
+  local yoe = y - era * 400
+  local doy = math.floor((153 * ((m + 9) % 12) + 2) / 5) + d - 1
+  local doe = yoe * 365 + math.floor(yoe / 4) - math.floor(yoe / 100) + doy
+  return era * 146097 + doe - 719468
+end
+
+-- parse_rfc3339 returns the number of seconds since the epoch for an RFC 3339 timestamp.
+local function parse_rfc3339(s)
+  local y, mo, d, h, mi, sec, frac, tz = s:match("^(%d%d%d%d)%-(%d%d)%-(%d%d)T(%d%d):(%d%d):(%d%d)(%.?%d*)(.*)$")
+  if y == nil then
+    return nil
+  end
+  local offset = 0
+  if tz ~= "Z" then
+    local sign, oh, om = tz:match("^([+-])(%d%d):(%d%d)$")
+    if sign == nil then
+      return nil
+    end
+    offset = (tonumber(oh) * 60 + tonumber(om)) * 60
+    if sign == "-" then
+      offset = -offset
+    end
+  end
+  local t = days_from_civil(tonumber(y), tonumber(mo), tonumber(d)) * 86400 + tonumber(h) * 3600 + tonumber(mi) * 60 + tonumber(sec) - offset
+  if frac ~= "" then
+    t = t + tonumber("0" .. frac)
+  end
+  return t
+end
+
+local months = {Jan = 1, Feb = 2, Mar = 3, Apr = 4, May = 5, Jun = 6, Jul = 7, Aug = 8, Sep = 9, Oct = 10, Nov = 11, Dec = 12}
+
+-- parse_rfc3164_time returns the number of seconds since the epoch for a timestamp like "Oct 11 22:14:15" in UTC in the current year.
+local function parse_rfc3164_time(mon, d, h, mi, sec)
+  local m = months[mon]
+  if m == nil then
+    return nil
+  end
+  local y = os.date("!*t").year
+  return days_from_civil(y, m, tonumber(d)) * 86400 + tonumber(h) * 3600 + tonumber(mi) * 60 + tonumber(sec)
+end
+
+local function nil_value(s)
+  if s == "-" then
+    return nil
+  end
+  return s
+end
+
+-- parse_structured_data parses RFC 5424 structured data at the start of s,
+-- and returns a table of SD-ID => {PARAM-NAME => PARAM-VALUE} and the remainder of s.
+local function parse_structured_data(s)
+  if s:sub(1, 1) == "-" then
+    return nil, s:sub(2)
+  end
+  local sd = {}
+  local i = 1
+  while s:sub(i, i) == "[" do
+    local id, j = s:match("^([^%s%]=\"]+)()", i + 1)
+    if id == nil then
+      return nil
+    end
+    local params = {}
+    i = j
+    while s:sub(i, i) == " " do
+      local name, k = s:match("^([^%s%]=\"]+)=\"()", i + 1)
+      if name == nil then
+        return nil
+      end
+      local value = {}
+      i = k
+      while true do
+        local c = s:sub(i, i)
+        if c == "" then
+          return nil
+        elseif c == "\\" and s:sub(i + 1, i + 1):match('["\\%]]') then
+          table.insert(value, s:sub(i + 1, i + 1))
+          i = i + 2
+        elseif c == '"' then
+          i = i + 1
+          break
+        else
+          table.insert(value, c)
+          i = i + 1
+        end
+      end
+      params[name] = table.concat(value)
+    end
+    if s:sub(i, i) ~= "]" then
+      return nil
+    end
+    i = i + 1
+    sd[id] = params
+  end
+  if i == 1 then
+    return nil
+  end
+  return sd, s:sub(i)
+end
+
+local function parse_rfc5424(s)
+  local pri, version, ts, hostname, appname, proc_id, msg_id, rest = s:match("^<(%d%d?%d?)>(%d%d?) (%S+) (%S+) (%S+) (%S+) (%S+) (.*)$")
+  if pri == nil then
+    return nil
+  end
+  local timestamp
+  if ts ~= "-" then
+    timestamp = parse_rfc3339(ts)
+    if timestamp == nil then
+      return nil
+    end
+  end
+  local sd, message = parse_structured_data(rest)
+  if message == nil then
+    return nil
+  end
+  if message:sub(1, 1) == " " then
+    message = message:sub(2)
+  elseif message ~= "" then
+    return nil
+  end
+  return {
+    priority = tonumber(pri),
+    hostname = nil_value(hostname),
+    appname = nil_value(appname),
+    proc_id = nil_value(proc_id),
+    msg_id = nil_value(msg_id),
+    structured_data = sd,
+    message = message,
+  }, timestamp
+end
+
+local function parse_rfc3164(s)
+  local pri, mon, d, h, mi, sec, hostname, rest = s:match("^<(%d%d?%d?)>(%a%a%a) +(%d%d?) (%d%d):(%d%d):(%d%d) (%S+) ?(.*)$")
+  if pri == nil then
+    return nil
+  end
+  local timestamp = parse_rfc3164_time(mon, d, h, mi, sec)
+  if timestamp == nil then
+    return nil
+  end
+  local appname, proc_id, message = rest:match("^([^%s%[:]+)%[([^%]]*)%]: ?(.*)$")
+  if appname == nil then
+    appname, message = rest:match("^([^%s%[:]+): ?(.*)$")
+  end
+  if appname == nil then
+    message = rest
+  end
+  return {
+    priority = tonumber(pri),
+    hostname = hostname,
+    appname = appname,
+    proc_id = proc_id,
+    message = message,
+  }, timestamp
+end
+
+function parse_syslog(tag, timestamp, record)
+  local message = record["message"]
+  if type(message) ~= "string" then
+    return 0, 0, 0
+  end
+  local parsed, parsed_timestamp = parse_rfc3164(message)
+  if parsed == nil or parsed.priority > 191 then
+    return 0, 0, 0
+  end
+  parsed.facility = math.floor(parsed.priority / 8)
+  if parsed.message == "" then
+    parsed.message = nil
+  end
+  record["message"] = nil
+  for k, v in pairs(parsed) do
+    record[k] = v
+  end
+  record["logging.googleapis.com/severity"] = severities[parsed.priority % 8 + 1]
+  if parsed_timestamp ~= nil then
+    return 1, parsed_timestamp, record
+  end
+  return 2, 0, record
+end
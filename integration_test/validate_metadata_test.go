@@ -15,8 +15,11 @@
 package integration
 
 import (
+	"bytes"
 	"embed"
+	"flag"
 	"fmt"
+	"go/format"
 	"io/fs"
 	"net/http"
 	"os"
@@ -33,6 +36,11 @@ var agentMetricsMetadata []byte
 //go:embed third_party_apps_test/applications
 var thirdPartyDataDir embed.FS
 
+var updateKnownMetrics = flag.Bool("update", false, "regenerate the known metrics of the third party apps")
+
+// thirdPartyAppMetricsFile contains the expected metrics of the third party apps, for the KnownMetrics of their metrics receivers.
+const thirdPartyAppMetricsFile = "../apps/third_party_app_metrics.go"
+
 func TestValidateMetadataOfThirdPartyApps(t *testing.T) {
 	err := walkThirdPartyApps(func(fullPath string, contents []byte) error {
 		app := path.Base(path.Dir(fullPath))
@@ -68,6 +76,60 @@ func TestRequireMetadataForAllThirdPartyApps(t *testing.T) {
 	}
 }
 
+func TestThirdPartyAppMetrics(t *testing.T) {
+	parentDirectory := "third_party_apps_test/applications"
+	dirs, err := thirdPartyDataDir.ReadDir(parentDirectory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	b.WriteString(`// Code generated by TestThirdPartyAppMetrics in integration_test; DO NOT EDIT.
+// Run "go test ./integration_test -run TestThirdPartyAppMetrics -update" after changing the expected_metrics of an app.
+
+package apps
+
+// thirdPartyAppMetrics contains the expected_metrics of each app in integration_test/third_party_apps_test/applications.
+var thirdPartyAppMetrics = map[string][]string{
+`)
+	for _, dir := range dirs {
+		fullPath := path.Join(parentDirectory, dir.Name(), "metadata.yaml")
+		contents, err := os.ReadFile(fullPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		integrationMetadata := &metadata.IntegrationMetadata{}
+		if err := metadata.UnmarshalAndValidate(fullPath, contents, integrationMetadata); err != nil {
+			t.Fatal(err)
+		}
+		if len(integrationMetadata.ExpectedMetrics) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "%q: {\n", dir.Name())
+		for _, m := range integrationMetadata.ExpectedMetrics {
+			fmt.Fprintf(&b, "%q,\n", m.Type)
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+	want, err := format.Source(b.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *updateKnownMetrics {
+		if err := os.WriteFile(thirdPartyAppMetricsFile, want, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	got, err := os.ReadFile(thirdPartyAppMetricsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date; run this test with -update to regenerate it", thirdPartyAppMetricsFile)
+	}
+}
+
 func TestThirdPartyPublicUrls(t *testing.T) {
 	err := walkThirdPartyApps(func(fullPath string, contents []byte) error {
 		app := path.Base(path.Dir(fullPath))
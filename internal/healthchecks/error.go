@@ -61,6 +61,54 @@ var (
 		ResourceLink: "https://cloud.google.com/monitoring/agent/ops-agent/troubleshooting",
 		IsFatal:      true,
 	}
+	ReceiverPortConflictErr = HealthCheckError{
+		Code:         "ReceiverPortConflictErr",
+		Class:        Port,
+		Message:      "Multiple components of the Ops Agent are configured to listen on the same port.",
+		Action:       "Configure each receiver to listen on a different port.",
+		ResourceLink: "https://cloud.google.com/stackdriver/docs/solutions/agents/ops-agent/configuration",
+		IsFatal:      true,
+	}
+	ReceiverPortUnavailableErr = HealthCheckError{
+		Code:         "ReceiverPortUnavailableErr",
+		Class:        Port,
+		Message:      "A port needed by a receiver is unavailable.",
+		Action:       "Verify that the port is not used by another process.",
+		ResourceLink: "https://cloud.google.com/stackdriver/docs/solutions/agents/ops-agent/troubleshoot-run-ingest",
+		IsFatal:      true,
+	}
+	ReceiverEndpointConnErr = HealthCheckError{
+		Code:         "ReceiverEndpointConnErr",
+		Class:        Connection,
+		Message:      "A receiver could not connect to its endpoint.",
+		Action:       "Verify that the application is running and that the endpoint is reachable from the VM.",
+		ResourceLink: "https://cloud.google.com/stackdriver/docs/solutions/agents/ops-agent/third-party",
+		IsFatal:      false,
+	}
+	LogFilesNotFoundErr = HealthCheckError{
+		Code:         "LogFilesNotFoundErr",
+		Class:        Generic,
+		Message:      "The include_paths of a logging receiver match no files.",
+		Action:       "Verify that the include_paths of the receiver match the log files of the application.",
+		ResourceLink: "https://cloud.google.com/stackdriver/docs/solutions/agents/ops-agent/configuration#logging-receivers",
+		IsFatal:      false,
+	}
+	LogFilesExcludedErr = HealthCheckError{
+		Code:         "LogFilesExcludedErr",
+		Class:        Generic,
+		Message:      "All files matched by a logging receiver are excluded by its exclude_paths.",
+		Action:       "Verify the exclude_paths of the receiver.",
+		ResourceLink: "https://cloud.google.com/stackdriver/docs/solutions/agents/ops-agent/configuration#logging-receivers",
+		IsFatal:      false,
+	}
+	LogFilesPermissionErr = HealthCheckError{
+		Code:         "LogFilesPermissionErr",
+		Class:        Permission,
+		Message:      "The Ops Agent cannot read files matched by a logging receiver.",
+		Action:       "Grant the Ops Agent read access to the log files.",
+		ResourceLink: "https://cloud.google.com/stackdriver/docs/solutions/agents/ops-agent/troubleshoot-run-ingest",
+		IsFatal:      false,
+	}
 	LogApiConnErr = HealthCheckError{
 		Code:         "LogApiConnErr",
 		Class:        Connection,
@@ -101,6 +149,30 @@ var (
 		ResourceLink: "https://cloud.google.com/stackdriver/docs/solutions/agents/ops-agent/troubleshoot-run-ingest#network-issues",
 		IsFatal:      true,
 	}
+	ProxyAuthErr = HealthCheckError{
+		Code:         "ProxyAuthErr",
+		Class:        Connection,
+		Message:      "The proxy requires authentication.",
+		Action:       "Verify the credentials in the HTTPS_PROXY setting of the Ops Agent.",
+		ResourceLink: "https://cloud.google.com/stackdriver/docs/solutions/agents/ops-agent/proxy-configuration",
+		IsFatal:      true,
+	}
+	TLSInterceptionErr = HealthCheckError{
+		Code:         "TLSInterceptionErr",
+		Class:        Connection,
+		Message:      "The server certificate is not trusted.",
+		Action:       "If a proxy intercepts TLS traffic, add the certificate of its CA to the trusted certificates of the VM.",
+		ResourceLink: "https://cloud.google.com/stackdriver/docs/solutions/agents/ops-agent/proxy-configuration",
+		IsFatal:      true,
+	}
+	DNSResolutionErr = HealthCheckError{
+		Code:         "DNSResolutionErr",
+		Class:        Connection,
+		Message:      "A host name could not be resolved.",
+		Action:       "Check the DNS configuration of the VM and the HTTPS_PROXY setting of the Ops Agent.",
+		ResourceLink: "https://cloud.google.com/stackdriver/docs/solutions/agents/ops-agent/troubleshoot-run-ingest#network-issues",
+		IsFatal:      true,
+	}
 	LogApiScopeErr = HealthCheckError{
 		Code:         "LogApiScopeErr",
 		Class:        Permission,
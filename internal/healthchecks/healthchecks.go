@@ -15,7 +15,9 @@
 package healthchecks
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -77,6 +79,71 @@ func (r HealthCheckResult) ErrorSlice() []error {
 	return []error{r.Err}
 }
 
+// A HealthCheckReport is the machine-readable form of a single result of a health check.
+type HealthCheckReport struct {
+	Name string `json:"name"`
+	// Result is one of PASS, WARNING, FAIL or ERROR.
+	Result       string `json:"result"`
+	Code         string `json:"code,omitempty"`
+	Message      string `json:"message,omitempty"`
+	Action       string `json:"action,omitempty"`
+	ResourceLink string `json:"resource_link,omitempty"`
+}
+
+// Reports returns a report for each of the errors of the health check, or a single PASS report.
+func (r HealthCheckResult) Reports() []HealthCheckReport {
+	var reports []HealthCheckReport
+	for _, e := range r.ErrorSlice() {
+		report := HealthCheckReport{Name: r.Name, Result: "PASS"}
+		if healthError, ok := e.(HealthCheckError); ok {
+			report.Result = "WARNING"
+			if healthError.IsFatal {
+				report.Result = "FAIL"
+			}
+			report.Code = healthError.Code
+			report.Message = healthError.Message
+			report.Action = healthError.Action
+			report.ResourceLink = healthError.ResourceLink
+		} else if e != nil {
+			report.Result = "ERROR"
+			report.Message = e.Error()
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// IsFatal returns true if any of the errors of the health check is fatal.
+func (r HealthCheckResult) IsFatal() bool {
+	for _, e := range r.ErrorSlice() {
+		if healthError, ok := e.(HealthCheckError); ok && healthError.IsFatal {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteHealthCheckResultsJSON writes the reports of all the health checks to w as a JSON array.
+func WriteHealthCheckResultsJSON(healthCheckResults []HealthCheckResult, w io.Writer) error {
+	reports := []HealthCheckReport{}
+	for _, result := range healthCheckResults {
+		reports = append(reports, result.Reports()...)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(reports)
+}
+
+// AnyFatalHealthCheck returns true if any of the health checks failed with a fatal error.
+func AnyFatalHealthCheck(healthCheckResults []HealthCheckResult) bool {
+	for _, result := range healthCheckResults {
+		if result.IsFatal() {
+			return true
+		}
+	}
+	return false
+}
+
 func LogHealthCheckResults(healthCheckResults []HealthCheckResult, logger logs.StructuredLogger) {
 	for _, result := range healthCheckResults {
 		result.LogResult(logger)
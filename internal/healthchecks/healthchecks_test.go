@@ -15,10 +15,23 @@
 package healthchecks_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/GoogleCloudPlatform/ops-agent/internal/healthchecks"
 	"github.com/GoogleCloudPlatform/ops-agent/internal/logs"
@@ -240,3 +253,380 @@ func TestMultipleSuccessResultCheck(t *testing.T) {
 	assert.Check(t, strings.Contains(observedLogs.All()[0].Entry.Message, expectedSuccess))
 	assert.Equal(t, observedLogs.All()[0].Entry.Level.String(), "info")
 }
+
+func TestWriteHealthCheckResultsJSON(t *testing.T) {
+	mCheck := MultipleFailureResultCheck{}
+	sCheck := SuccessCheck{}
+	testLogger, _ := logs.DiscardLogger()
+	results := []healthchecks.HealthCheckResult{
+		{Name: mCheck.Name(), Err: mCheck.RunCheck(testLogger)},
+		{Name: sCheck.Name(), Err: sCheck.RunCheck(testLogger)},
+	}
+
+	var b strings.Builder
+	err := healthchecks.WriteHealthCheckResultsJSON(results, &b)
+	assert.NilError(t, err)
+
+	var reports []healthchecks.HealthCheckReport
+	assert.NilError(t, json.Unmarshal([]byte(b.String()), &reports))
+	assert.DeepEqual(t, reports, []healthchecks.HealthCheckReport{
+		{Name: mCheck.Name(), Result: "ERROR", Message: "Test error."},
+		{Name: mCheck.Name(), Result: "WARNING", Code: "TestWarning"},
+		{Name: mCheck.Name(), Result: "FAIL", Code: "TestFailure"},
+		{Name: sCheck.Name(), Result: "PASS"},
+	})
+}
+
+func TestAnyFatalHealthCheck(t *testing.T) {
+	testLogger, _ := logs.DiscardLogger()
+	result := func(c healthchecks.HealthCheck) healthchecks.HealthCheckResult {
+		return healthchecks.HealthCheckResult{Name: c.Name(), Err: c.RunCheck(testLogger)}
+	}
+
+	assert.Check(t, !healthchecks.AnyFatalHealthCheck([]healthchecks.HealthCheckResult{
+		result(SuccessCheck{}), result(WarningCheck{}), result(ErrorCheck{}),
+	}))
+	assert.Check(t, healthchecks.AnyFatalHealthCheck([]healthchecks.HealthCheckResult{
+		result(SuccessCheck{}), result(FailureCheck{}),
+	}))
+	assert.Check(t, healthchecks.AnyFatalHealthCheck([]healthchecks.HealthCheckResult{
+		result(MultipleFailureResultCheck{}),
+	}))
+}
+
+func TestReceiversCheckPortConflicts(t *testing.T) {
+	check := healthchecks.ReceiversCheck{
+		ListenAddresses: map[string]healthchecks.ListenAddress{
+			"tcp_1":    {Protocol: "tcp", Host: "127.0.0.1", Port: 5170},
+			"tcp_2":    {Protocol: "tcp", Host: "127.0.0.1", Port: 5170},
+			"syslog_1": {Protocol: "udp", Host: "0.0.0.0", Port: 5140},
+			"syslog_2": {Protocol: "udp", Host: "10.0.0.1", Port: 5140},
+			"otlp":     {Protocol: "tcp", Host: "0.0.0.0", Port: 20201},
+		},
+	}
+	testLogger, _ := logs.DiscardLogger()
+	result := healthchecks.HealthCheckResult{Name: check.Name(), Err: check.RunCheck(testLogger)}
+
+	assert.DeepEqual(t, result.Reports(), []healthchecks.HealthCheckReport{
+		{
+			Name:         check.Name(),
+			Result:       "FAIL",
+			Code:         "ReceiverPortConflictErr",
+			Message:      `Receivers "syslog_1", "syslog_2" are all configured to listen on UDP port 5140.`,
+			Action:       `Configure receivers "syslog_1", "syslog_2" to listen on different ports.`,
+			ResourceLink: healthchecks.ReceiverPortConflictErr.ResourceLink,
+		},
+		{
+			Name:         check.Name(),
+			Result:       "FAIL",
+			Code:         "ReceiverPortConflictErr",
+			Message:      `Receivers "tcp_1", "tcp_2" are all configured to listen on TCP port 5170.`,
+			Action:       `Configure receivers "tcp_1", "tcp_2" to listen on different ports.`,
+			ResourceLink: healthchecks.ReceiverPortConflictErr.ResourceLink,
+		},
+		{
+			Name:         check.Name(),
+			Result:       "FAIL",
+			Code:         "ReceiverPortConflictErr",
+			Message:      `Receiver "otlp" is configured to listen on TCP port 20201, which is needed for Ops Agent self metrics.`,
+			Action:       `Configure receiver "otlp" to listen on a different port.`,
+			ResourceLink: healthchecks.ReceiverPortConflictErr.ResourceLink,
+		},
+	})
+}
+
+func TestReceiversCheckTargetAddresses(t *testing.T) {
+	reachable, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer reachable.Close()
+	unreachable, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	unreachable.Close()
+
+	check := healthchecks.ReceiversCheck{
+		TargetAddresses: map[string]string{
+			"mysql": reachable.Addr().String(),
+			"redis": unreachable.Addr().String(),
+		},
+	}
+	testLogger, _ := logs.DiscardLogger()
+	result := healthchecks.HealthCheckResult{Name: check.Name(), Err: check.RunCheck(testLogger)}
+
+	assert.DeepEqual(t, result.Reports(), []healthchecks.HealthCheckReport{
+		{
+			Name:         check.Name(),
+			Result:       "WARNING",
+			Code:         "ReceiverEndpointConnErr",
+			Message:      fmt.Sprintf(`Receiver "redis" could not connect to %s.`, unreachable.Addr()),
+			Action:       fmt.Sprintf(`Verify that the application monitored by receiver "redis" is running and reachable at %s.`, unreachable.Addr()),
+			ResourceLink: healthchecks.ReceiverEndpointConnErr.ResourceLink,
+		},
+	})
+}
+
+func TestLogFilesCheck(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"app.log", "app.log.1", "other.log", "nested/top.log", "nested/a/deep.log", "nested/b/skip/deep.log"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NilError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NilError(t, os.WriteFile(path, []byte("line\n"), 0644))
+	}
+
+	check := healthchecks.LogFilesCheck{
+		IncludePaths: map[string][]string{
+			"app":      {filepath.Join(dir, "app.log*"), filepath.Join(dir, "missing.log")},
+			"excluded": {filepath.Join(dir, "other.log")},
+			"missing":  {filepath.Join(dir, "missing.log"), filepath.Join(dir, "**", "missing.log")},
+			"nested":   {filepath.Join(dir, "nested", "**", "*.log")},
+		},
+		ExcludePaths: map[string][]string{
+			"app":      {filepath.Join(dir, "*.1")},
+			"excluded": {filepath.Join(dir, "*.log")},
+			// "*" matches "/" in exclude_paths.
+			"nested": {filepath.Join(dir, "nested", "*", "skip*")},
+		},
+	}
+	testLogger, observedLogs := logs.DiscardLogger()
+	result := healthchecks.HealthCheckResult{Name: check.Name(), Err: check.RunCheck(testLogger)}
+
+	assert.DeepEqual(t, result.Reports(), []healthchecks.HealthCheckReport{
+		{
+			Name:         check.Name(),
+			Result:       "WARNING",
+			Code:         "LogFilesExcludedErr",
+			Message:      fmt.Sprintf(`All files matched by receiver "excluded" are excluded by its exclude_paths: %s.`, filepath.Join(dir, "other.log")),
+			Action:       `Verify the exclude_paths of receiver "excluded".`,
+			ResourceLink: healthchecks.LogFilesExcludedErr.ResourceLink,
+		},
+		{
+			Name:         check.Name(),
+			Result:       "WARNING",
+			Code:         "LogFilesNotFoundErr",
+			Message:      fmt.Sprintf(`The include_paths %s, %s of receiver "missing" match no files.`, filepath.Join(dir, "missing.log"), filepath.Join(dir, "**", "missing.log")),
+			Action:       `Verify that the include_paths of receiver "missing" match the log files of the application.`,
+			ResourceLink: healthchecks.LogFilesNotFoundErr.ResourceLink,
+		},
+	})
+
+	var messages []string
+	for _, entry := range observedLogs.All() {
+		messages = append(messages, entry.Message)
+	}
+	assert.DeepEqual(t, messages, []string{
+		fmt.Sprintf(`the include_paths %s of receiver "app" match no files`, filepath.Join(dir, "missing.log")),
+		fmt.Sprintf(`receiver "app" excludes files %s`, filepath.Join(dir, "app.log.1")),
+		`receiver "app" can read 1 files`,
+		fmt.Sprintf(`receiver "nested" excludes files %s`, filepath.Join(dir, "nested", "b", "skip", "deep.log")),
+		`receiver "nested" can read 2 files`,
+	})
+}
+
+func TestLogFilesCheckPermission(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test relies on a Unix socket that can't be opened as a file")
+	}
+	// Unlike a file without read permission, a socket can't be opened even by root.
+	dir := t.TempDir()
+	socket := filepath.Join(dir, "app.log")
+	ln, err := net.Listen("unix", socket)
+	assert.NilError(t, err)
+	defer ln.Close()
+
+	check := healthchecks.LogFilesCheck{
+		IncludePaths: map[string][]string{"app": {filepath.Join(dir, "*.log")}},
+	}
+	testLogger, _ := logs.DiscardLogger()
+	result := healthchecks.HealthCheckResult{Name: check.Name(), Err: check.RunCheck(testLogger)}
+
+	assert.DeepEqual(t, result.Reports(), []healthchecks.HealthCheckReport{
+		{
+			Name:         check.Name(),
+			Result:       "WARNING",
+			Code:         "LogFilesPermissionErr",
+			Message:      fmt.Sprintf(`Receiver "app" cannot read files %s.`, socket),
+			Action:       `Grant the Ops Agent read access to the files collected by receiver "app".`,
+			ResourceLink: healthchecks.LogFilesPermissionErr.ResourceLink,
+		},
+	})
+}
+
+func TestHealthCheckResultStatus(t *testing.T) {
+	testLogger, _ := logs.DiscardLogger()
+	for _, tc := range []struct {
+		check healthchecks.HealthCheck
+		want  string
+	}{
+		{SuccessCheck{}, "PASS"},
+		{WarningCheck{}, "WARNING"},
+		{ErrorCheck{}, "ERROR"},
+		{FailureCheck{}, "FAIL"},
+		{MultipleFailureResultCheck{}, "FAIL"},
+	} {
+		result := healthchecks.HealthCheckResult{Name: tc.check.Name(), Err: tc.check.RunCheck(testLogger)}
+		assert.Equal(t, result.Status(), tc.want, tc.check.Name())
+	}
+}
+
+func TestHealthCheckRegistrySelect(t *testing.T) {
+	registry := healthchecks.HealthCheckRegistry{FailureCheck{}, WarningCheck{}, SuccessCheck{}}
+
+	selected, err := registry.Select("success", "Failure Check")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, selected, healthchecks.HealthCheckRegistry{SuccessCheck{}, FailureCheck{}})
+
+	_, err = registry.Select("missing")
+	assert.ErrorContains(t, err, `unknown health check "missing"`)
+
+	results := []healthchecks.HealthCheckResult{
+		{Name: FailureCheck{}.Name()},
+		{Name: WarningCheck{}.Name()},
+		{Name: SuccessCheck{}.Name()},
+	}
+	assert.DeepEqual(t, selected.SelectResults(results), []healthchecks.HealthCheckResult{
+		{Name: FailureCheck{}.Name()},
+		{Name: SuccessCheck{}.Name()},
+	})
+}
+
+// FlakyCheck fails on every run after the first one.
+type FlakyCheck struct {
+	runs *atomic.Int32
+}
+
+func (c FlakyCheck) Name() string {
+	return "Flaky Check"
+}
+
+func (c FlakyCheck) RunCheck(logger logs.StructuredLogger) error {
+	if c.runs.Add(1) > 1 {
+		return TestFailure
+	}
+	return nil
+}
+
+func TestPeriodicHealthChecks(t *testing.T) {
+	check := FlakyCheck{runs: &atomic.Int32{}}
+	testLogger, observedLogs := logs.DiscardLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var statuses []string
+	healthchecks.PeriodicHealthChecks{
+		Registry: healthchecks.HealthCheckRegistry{check},
+		Interval: time.Millisecond,
+		OnResults: func(results []healthchecks.HealthCheckResult) {
+			statuses = append(statuses, results[0].Status())
+			if len(statuses) == 3 {
+				cancel()
+			}
+		},
+	}.Run(ctx, testLogger, []healthchecks.HealthCheckResult{{Name: check.Name()}})
+
+	assert.DeepEqual(t, statuses, []string{"PASS", "FAIL", "FAIL"})
+	transitions := observedLogs.FilterMessageSnippet("Status changed").All()
+	assert.Equal(t, len(transitions), 1)
+	assert.Equal(t, transitions[0].Message, "[Flaky Check] Status changed from PASS to FAIL")
+}
+
+// newStandInProxy starts a proxy that requires basic authentication, tunnels
+// every CONNECT request to target and answers plain HTTP requests with 502.
+func newStandInProxy(t *testing.T, target string) *httptest.Server {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &http.Request{Header: http.Header{"Authorization": r.Header["Proxy-Authorization"]}}
+		if user, password, ok := req.BasicAuth(); !ok || user != "agent" || password != "secret" {
+			w.Header().Set("Proxy-Authenticate", `Basic realm="proxy"`)
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		upstream, err := net.Dial("tcp", target)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		conn, _, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			upstream.Close()
+			return
+		}
+		go func() {
+			defer upstream.Close()
+			defer conn.Close()
+			go io.Copy(upstream, conn)
+			io.Copy(conn, upstream)
+		}()
+	}))
+	t.Cleanup(proxy.Close)
+	return proxy
+}
+
+func fixedProxy(proxyURL *url.URL) func(*url.URL) (*url.URL, error) {
+	return func(*url.URL) (*url.URL, error) { return proxyURL, nil }
+}
+
+func networkCheckCodes(t *testing.T, check healthchecks.NetworkCheck) []string {
+	testLogger, _ := logs.DiscardLogger()
+	result := healthchecks.HealthCheckResult{Name: check.Name(), Err: check.RunCheck(testLogger)}
+	var codes []string
+	for _, report := range result.Reports() {
+		codes = append(codes, report.Code)
+	}
+	return codes
+}
+
+func TestNetworkCheckProxyAuthentication(t *testing.T) {
+	proxy := newStandInProxy(t, "127.0.0.1:1")
+	proxyURL, err := url.Parse(proxy.URL)
+	assert.NilError(t, err)
+	proxyURL.User = url.UserPassword("agent", "wrong")
+
+	check := healthchecks.NetworkCheck{Proxy: fixedProxy(proxyURL)}
+	testLogger, observedLogs := logs.DiscardLogger()
+	result := healthchecks.HealthCheckResult{Name: check.Name(), Err: check.RunCheck(testLogger)}
+
+	reports := result.Reports()
+	assert.Equal(t, len(reports), 5)
+	for _, report := range reports {
+		assert.Equal(t, report.Code, "ProxyAuthErr")
+	}
+	assert.Equal(t, reports[0].Message, fmt.Sprintf("Request to Logging API through proxy %s failed: the proxy requires authentication.", proxyURL.Redacted()))
+	assert.Equal(t, observedLogs.FilterMessage(fmt.Sprintf("Logging API request uses proxy %s", proxyURL.Redacted())).Len(), 1)
+}
+
+func TestNetworkCheckTLSInterception(t *testing.T) {
+	interceptor := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	// The client aborts every handshake, which the server would log.
+	interceptor.Config.ErrorLog = log.New(io.Discard, "", 0)
+	interceptor.StartTLS()
+	defer interceptor.Close()
+	proxy := newStandInProxy(t, interceptor.Listener.Addr().String())
+	proxyURL, err := url.Parse(proxy.URL)
+	assert.NilError(t, err)
+	proxyURL.User = url.UserPassword("agent", "secret")
+
+	codes := networkCheckCodes(t, healthchecks.NetworkCheck{Proxy: fixedProxy(proxyURL)})
+	assert.DeepEqual(t, codes, []string{
+		"TLSInterceptionErr",
+		"TLSInterceptionErr",
+		"TLSInterceptionErr",
+		"TLSInterceptionErr",
+		"MetaApiConnErr",
+	})
+}
+
+func TestNetworkCheckProxyDNSResolution(t *testing.T) {
+	proxyURL := &url.URL{Scheme: "http", Host: "proxy.invalid:3128"}
+
+	codes := networkCheckCodes(t, healthchecks.NetworkCheck{Proxy: fixedProxy(proxyURL)})
+	assert.DeepEqual(t, codes, []string{
+		"DNSResolutionErr",
+		"DNSResolutionErr",
+		"DNSResolutionErr",
+		"DNSResolutionErr",
+		"DNSResolutionErr",
+	})
+}
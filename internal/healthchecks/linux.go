@@ -19,8 +19,10 @@ package healthchecks
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"os/exec"
+	"strings"
 	"syscall"
 )
 
@@ -37,6 +39,93 @@ func isSubagentActive(subagent string) (bool, error) {
 	return true, nil
 }
 
+// subagentEnvironment returns the Environment= settings of the systemd unit of
+// a subagent, including those of its drop-in overrides.
+func subagentEnvironment(subagent string) (map[string]string, error) {
+	output, err := exec.Command("systemctl", "show", "--property=Environment", "--value", subagent).Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseSystemdEnvironment(string(output))
+}
+
+// parseSystemdEnvironment parses the Environment= settings printed by systemctl
+// show, which quotes and escapes the assignments that contain spaces or
+// special characters.
+func parseSystemdEnvironment(output string) (map[string]string, error) {
+	words, err := splitSystemdWords(output)
+	if err != nil {
+		return nil, err
+	}
+	env := map[string]string{}
+	for _, kv := range words {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	return env, nil
+}
+
+// splitSystemdWords splits s into words separated by whitespace, removing the
+// single and double quotes and the backslash escapes of the words, like systemd
+// does for the assignments of its unit files.
+func splitSystemdWords(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, c := range s {
+		switch {
+		case escaped:
+			word.WriteString(unescapeSystemdChar(c))
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				word.WriteRune(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+			inWord = true
+		case strings.ContainsRune(" \t\n\r", c):
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+	if escaped || quote != 0 {
+		return nil, fmt.Errorf("unterminated quote or escape in %q", s)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// unescapeSystemdChar returns the character escaped by a backslash followed by c.
+func unescapeSystemdChar(c rune) string {
+	switch c {
+	case 'n':
+		return "\n"
+	case 't':
+		return "\t"
+	case 'r':
+		return "\r"
+	default:
+		return string(c)
+	}
+}
+
 func isPortUnavailableError(err error) bool {
 	return errors.Is(err, syscall.EADDRINUSE)
 }
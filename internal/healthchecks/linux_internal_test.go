@@ -0,0 +1,71 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package healthchecks
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseSystemdEnvironment(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		output string
+		want   map[string]string
+	}{
+		{
+			name:   "empty",
+			output: "\n",
+			want:   map[string]string{},
+		},
+		{
+			name:   "plain",
+			output: "HTTPS_PROXY=http://proxy:3128 NO_PROXY=localhost,169.254.169.254\n",
+			want:   map[string]string{"HTTPS_PROXY": "http://proxy:3128", "NO_PROXY": "localhost,169.254.169.254"},
+		},
+		{
+			name:   "double quotes",
+			output: `"HTTPS_PROXY=http://user:p a\"ss@proxy:3128" NO_PROXY=localhost` + "\n",
+			want:   map[string]string{"HTTPS_PROXY": `http://user:p a"ss@proxy:3128`, "NO_PROXY": "localhost"},
+		},
+		{
+			name:   "single quotes",
+			output: `'HTTPS_PROXY=http://proxy:3128/\x' HTTP_PROXY=http://proxy:3128`,
+			want:   map[string]string{"HTTPS_PROXY": `http://proxy:3128/\x`, "HTTP_PROXY": "http://proxy:3128"},
+		},
+		{
+			name:   "escapes",
+			output: `HTTPS_PROXY=http://proxy:3128/a\ b OTHER=x\ty`,
+			want:   map[string]string{"HTTPS_PROXY": "http://proxy:3128/a b", "OTHER": "x\ty"},
+		},
+		{
+			name:   "no value",
+			output: `EMPTY= "QUOTED=" NOT_AN_ASSIGNMENT`,
+			want:   map[string]string{"EMPTY": "", "QUOTED": ""},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			env, err := parseSystemdEnvironment(tc.output)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, env, tc.want)
+		})
+	}
+
+	_, err := parseSystemdEnvironment(`"HTTPS_PROXY=http://proxy:3128`)
+	assert.ErrorContains(t, err, "unterminated quote")
+}
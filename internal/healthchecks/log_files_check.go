@@ -0,0 +1,288 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthchecks
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/ops-agent/internal/logs"
+)
+
+// LogFilesCheck verifies that the logging receivers of the merged agent config
+// that tail files can find and read the files that they are configured to collect.
+//
+// Files are matched the way the fluent-bit tail input matches them: a "**" path
+// element in include_paths matches any number of directories, and exclude_paths
+// are matched against the whole path with fnmatch without FNM_PATHNAME, so "*"
+// and "?" also match the path separator.
+type LogFilesCheck struct {
+	// IncludePaths maps the IDs of receivers that tail files to their include_paths.
+	IncludePaths map[string][]string
+	// ExcludePaths maps the IDs of receivers that tail files to their exclude_paths.
+	ExcludePaths map[string][]string
+}
+
+func (c LogFilesCheck) Name() string {
+	return "Log Files Check"
+}
+
+func (c LogFilesCheck) RunCheck(logger logs.StructuredLogger) error {
+	rIDs := make([]string, 0, len(c.IncludePaths))
+	for rID := range c.IncludePaths {
+		rIDs = append(rIDs, rID)
+	}
+	sort.Strings(rIDs)
+
+	var errs []error
+	for _, rID := range rIDs {
+		if err := runLogFilesCheck(logger, rID, c.IncludePaths[rID], c.ExcludePaths[rID]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runLogFilesCheck expands the include_paths of a receiver and reports when no
+// file is left to collect after exclude_paths, or when some files can't be read.
+func runLogFilesCheck(logger logs.StructuredLogger, rID string, includePaths, excludePaths []string) error {
+	var files, unmatched []string
+	seen := map[string]bool{}
+	for _, pattern := range includePaths {
+		matches, err := globLogFiles(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid include_paths pattern %q of receiver %q: %w", pattern, rID, err)
+		}
+		found := false
+		for _, m := range matches {
+			if info, err := os.Stat(m); err != nil || info.IsDir() {
+				continue
+			}
+			found = true
+			if !seen[m] {
+				seen[m] = true
+				files = append(files, m)
+			}
+		}
+		if !found {
+			unmatched = append(unmatched, pattern)
+		}
+	}
+	if len(files) == 0 {
+		hcErr := LogFilesNotFoundErr
+		hcErr.Message = fmt.Sprintf("The include_paths %s of receiver %q match no files.", strings.Join(unmatched, ", "), rID)
+		hcErr.Action = fmt.Sprintf("Verify that the include_paths of receiver %q match the log files of the application.", rID)
+		return hcErr
+	}
+	if len(unmatched) > 0 {
+		logger.Infof("the include_paths %s of receiver %q match no files", strings.Join(unmatched, ", "), rID)
+	}
+
+	var included, excluded []string
+	for _, f := range files {
+		if isExcludedLogFile(f, excludePaths) {
+			excluded = append(excluded, f)
+		} else {
+			included = append(included, f)
+		}
+	}
+	if len(included) == 0 {
+		hcErr := LogFilesExcludedErr
+		hcErr.Message = fmt.Sprintf("All files matched by receiver %q are excluded by its exclude_paths: %s.", rID, strings.Join(excluded, ", "))
+		hcErr.Action = fmt.Sprintf("Verify the exclude_paths of receiver %q.", rID)
+		return hcErr
+	}
+	if len(excluded) > 0 {
+		logger.Infof("receiver %q excludes files %s", rID, strings.Join(excluded, ", "))
+	}
+
+	var unreadable []string
+	for _, f := range included {
+		file, err := os.Open(f)
+		if err != nil {
+			unreadable = append(unreadable, f)
+			continue
+		}
+		file.Close()
+	}
+	if len(unreadable) > 0 {
+		hcErr := LogFilesPermissionErr
+		hcErr.Message = fmt.Sprintf("Receiver %q cannot read files %s.", rID, strings.Join(unreadable, ", "))
+		hcErr.Action = fmt.Sprintf("Grant the Ops Agent read access to the files collected by receiver %q.", rID)
+		return hcErr
+	}
+	logger.Infof("receiver %q can read %d files", rID, len(included))
+	return nil
+}
+
+// globLogFiles returns the paths matching pattern, where a "**" path element
+// matches zero or more directories.
+func globLogFiles(pattern string) ([]string, error) {
+	pattern = filepath.FromSlash(pattern)
+	elems := strings.Split(pattern, string(filepath.Separator))
+	recursive := -1
+	for i, elem := range elems {
+		if elem == "**" {
+			recursive = i
+			break
+		}
+	}
+	if recursive == -1 {
+		return filepath.Glob(pattern)
+	}
+	// Check the syntax of the elements that filepath.Glob doesn't see.
+	for _, elem := range elems[recursive:] {
+		if _, err := filepath.Match(elem, ""); err != nil {
+			return nil, err
+		}
+	}
+	root := strings.Join(elems[:recursive], string(filepath.Separator))
+	if root == "" && recursive > 0 {
+		root = string(filepath.Separator)
+	}
+	roots := []string{root}
+	if root == "" {
+		roots = []string{"."}
+	} else if hasMeta(root) {
+		var err error
+		if roots, err = filepath.Glob(root); err != nil {
+			return nil, err
+		}
+	}
+	var matches []string
+	for _, root := range roots {
+		filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || path == root {
+				return nil
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return nil
+			}
+			if matchPathElements(elems[recursive:], strings.Split(rel, string(filepath.Separator))) {
+				matches = append(matches, path)
+			}
+			return nil
+		})
+	}
+	return matches, nil
+}
+
+// matchPathElements reports whether the path elements match the pattern
+// elements, where a "**" pattern element matches zero or more path elements.
+func matchPathElements(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchPathElements(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		return false
+	}
+	if ok, _ := filepath.Match(pattern[0], path[0]); !ok {
+		return false
+	}
+	return matchPathElements(pattern[1:], path[1:])
+}
+
+func hasMeta(path string) bool {
+	magicChars := `*?[`
+	if filepath.Separator != '\\' {
+		magicChars = `*?[\`
+	}
+	return strings.ContainsAny(path, magicChars)
+}
+
+// fnmatchRegexp translates an fnmatch pattern matched without FNM_PATHNAME,
+// where wildcards also match the path separator, to a regular expression.
+func fnmatchRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			b.WriteString("(?s:.*)")
+		case '?':
+			b.WriteString("(?s:.)")
+		case '[':
+			// A "!" or "^" negates the set, and a "]" right after them or
+			// the "[" is part of the set.
+			j := i + 1
+			if j < len(pattern) && (pattern[j] == '!' || pattern[j] == '^') {
+				j++
+			}
+			if j < len(pattern) && pattern[j] == ']' {
+				j++
+			}
+			end := strings.IndexByte(pattern[j:], ']')
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta("["))
+				continue
+			}
+			end += j
+			set := pattern[i+1 : end]
+			b.WriteString("[")
+			if set[0] == '!' || set[0] == '^' {
+				b.WriteString("^")
+				set = set[1:]
+			}
+			for _, r := range set {
+				if r == '-' {
+					b.WriteRune(r)
+				} else {
+					b.WriteString(regexp.QuoteMeta(string(r)))
+				}
+			}
+			b.WriteString("]")
+			i = end
+		case '\\':
+			if filepath.Separator != '\\' && i+1 < len(pattern) {
+				i++
+				b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+				continue
+			}
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+func isExcludedLogFile(path string, excludePaths []string) bool {
+	for _, pattern := range excludePaths {
+		re, err := fnmatchRegexp(filepath.FromSlash(pattern))
+		if err != nil {
+			continue
+		}
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package healthchecks
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestIsExcludedLogFile(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"/var/log/*.log", "/var/log/app.log", true},
+		{"/var/log/*.log", "/var/log/app/app.log", true},
+		{"/var/log/*", "/var/log/app/current", true},
+		{"/var/log/app?log", "/var/log/app/log", true},
+		{"/var/log/*.log", "/var/log/app.log.1", false},
+		{"/var/log/app.[0-9]", "/var/log/app.1", true},
+		{"/var/log/app.[!0-9]", "/var/log/app.1", false},
+		{"/var/log/app.[!0-9]", "/var/log/app.x", true},
+		{"/var/log/app.[]x]", "/var/log/app.]", true},
+		{"/var/log/app.[", "/var/log/app.[", true},
+		{`/var/log/app.\*`, "/var/log/app.*", true},
+		{`/var/log/app.\*`, "/var/log/app.1", false},
+		{"/var/log/app.(1)", "/var/log/app.(1)", true},
+	} {
+		assert.Equal(t, isExcludedLogFile(tc.path, []string{tc.pattern}), tc.want, "pattern %q, path %q", tc.pattern, tc.path)
+	}
+}
+
+func TestMatchPathElements(t *testing.T) {
+	for _, tc := range []struct {
+		pattern []string
+		path    []string
+		want    bool
+	}{
+		{[]string{"**", "*.log"}, []string{"app.log"}, true},
+		{[]string{"**", "*.log"}, []string{"a", "b", "app.log"}, true},
+		{[]string{"**", "*.log"}, []string{"a", "b", "app.txt"}, false},
+		{[]string{"*", "**", "app", "*.log"}, []string{"a", "app", "x.log"}, true},
+		{[]string{"*", "**", "app", "*.log"}, []string{"app", "x.log"}, false},
+		{[]string{"**"}, []string{"a", "b"}, true},
+	} {
+		assert.Equal(t, matchPathElements(tc.pattern, tc.path), tc.want, "pattern %q, path %q", tc.pattern, tc.path)
+	}
+}
@@ -16,18 +16,34 @@ package healthchecks
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"sync"
 	"time"
 
 	"github.com/GoogleCloudPlatform/ops-agent/internal/logs"
 	"github.com/GoogleCloudPlatform/ops-agent/internal/platform"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// The systemd units of the subagents. A network request uses the proxy settings
+// of the subagent that sends it: fluent-bit sends the Logging API requests, and
+// the OpenTelemetry collector the others, including the requests that stand in
+// for the package manager.
+const (
+	fluentBitSubagent = "google-cloud-ops-agent-fluent-bit"
+	otelSubagent      = "google-cloud-ops-agent-opentelemetry-collector"
 )
 
 type networkRequest struct {
 	name             string
 	url              string
+	subagent         string
 	successMessage   string
 	healthCheckError HealthCheckError
 }
@@ -37,12 +53,14 @@ var (
 		{
 			name:             "Logging API",
 			url:              "https://logging.googleapis.com/$discovery/rest",
+			subagent:         fluentBitSubagent,
 			successMessage:   "Request to the Logging API was successful.",
 			healthCheckError: LogApiConnErr,
 		},
 		{
 			name:             "Monitoring API",
 			url:              "https://monitoring.googleapis.com/$discovery/rest",
+			subagent:         otelSubagent,
 			successMessage:   "Request to the Monitoring API was successful.",
 			healthCheckError: MonApiConnErr,
 		},
@@ -52,12 +70,14 @@ var (
 			// that we *can* fetch it at all, regardless of what distro we're running
 			// under.
 			url:              "https://packages.cloud.google.com/yum/doc/rpm-package-key.gpg",
+			subagent:         otelSubagent,
 			successMessage:   "Request to packages.cloud.google.com was successful.",
 			healthCheckError: PacApiConnErr,
 		},
 		{
 			name:             "dl.google.com",
 			url:              "https://dl.google.com/cloudagents/add-google-cloud-ops-agent-repo.sh",
+			subagent:         otelSubagent,
 			successMessage:   "Request to dl.google.com was successful.",
 			healthCheckError: DLApiConnErr,
 		},
@@ -66,36 +86,131 @@ var (
 		{
 			name:             "GCE Metadata Server",
 			url:              "http://metadata.google.internal",
+			subagent:         otelSubagent,
 			successMessage:   "Request to the GCE Metadata server was successful.",
 			healthCheckError: MetaApiConnErr,
 		},
 	}
 )
 
-func (r networkRequest) SendRequest(logger logs.StructuredLogger) error {
+// errProxyAuthRequired is returned when a proxy answers a CONNECT request with 407 Proxy Authentication Required.
+var errProxyAuthRequired = errors.New("proxy authentication required")
+
+func (r networkRequest) SendRequest(logger logs.StructuredLogger, proxy func(*url.URL) (*url.URL, error), tlsConfig *tls.Config) error {
+	requestURL, err := url.Parse(r.url)
+	if err != nil {
+		return err
+	}
+	proxyURL, err := proxy(requestURL)
+	if err != nil {
+		return err
+	}
+	if proxyURL != nil {
+		logger.Infof("%s request uses proxy %s", r.name, proxyURL.Redacted())
+	}
+
 	client := &http.Client{
 		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyURL(proxyURL),
+			TLSClientConfig: tlsConfig,
+			OnProxyConnectResponse: func(_ context.Context, _ *url.URL, _ *http.Request, res *http.Response) error {
+				if res.StatusCode == http.StatusProxyAuthRequired {
+					return errProxyAuthRequired
+				}
+				return nil
+			},
+		},
 	}
 
 	response, err := client.Get(r.url)
 	if err != nil {
-		if isTimeoutError(err) || isConnectionRefusedError(err) {
-			return r.healthCheckError
-		}
-		return err
+		return r.requestError(err, proxyURL)
 	}
 	defer response.Body.Close()
 	logger.Infof("%s response status: %s", r.name, response.Status)
 	switch response.StatusCode {
 	case http.StatusOK:
 		logger.Infof(r.successMessage)
+	case http.StatusProxyAuthRequired:
+		return r.requestError(errProxyAuthRequired, proxyURL)
 	default:
 		return r.healthCheckError
 	}
 	return nil
 }
 
-type NetworkCheck struct{}
+// requestError converts the error of a request into the HealthCheckError that
+// best describes it. The error keeps the severity of the request.
+func (r networkRequest) requestError(err error, proxyURL *url.URL) error {
+	via := ""
+	if proxyURL != nil {
+		via = fmt.Sprintf(" through proxy %s", proxyURL.Redacted())
+	}
+	var hcErr HealthCheckError
+	var dnsErr *net.DNSError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	switch {
+	case errors.Is(err, errProxyAuthRequired):
+		hcErr = ProxyAuthErr
+		hcErr.Message = fmt.Sprintf("Request to %s%s failed: the proxy requires authentication.", r.name, via)
+	case errors.As(err, &unknownAuthorityErr), errors.As(err, &hostnameErr):
+		hcErr = TLSInterceptionErr
+		hcErr.Message = fmt.Sprintf("Request to %s%s failed: the server certificate is not trusted.", r.name, via)
+	case errors.As(err, &dnsErr):
+		hcErr = DNSResolutionErr
+		hcErr.Message = fmt.Sprintf("Request to %s%s failed: %s could not be resolved.", r.name, via, dnsErr.Name)
+	case isTimeoutError(err) || isConnectionRefusedError(err):
+		return r.healthCheckError
+	default:
+		return err
+	}
+	hcErr.IsFatal = r.healthCheckError.IsFatal
+	return hcErr
+}
+
+// proxyFromEnvironment returns the proxy settings that a subagent uses: the
+// HTTPS_PROXY, HTTP_PROXY and NO_PROXY variables of the environment, overridden
+// by the Environment= settings of the systemd unit of the subagent.
+func proxyFromEnvironment(logger logs.StructuredLogger, subagent string) func(*url.URL) (*url.URL, error) {
+	env, err := subagentEnvironment(subagent)
+	if err != nil {
+		logger.Infof("failed to read the environment of %s: %v", subagent, err)
+	}
+	config := overrideProxyConfig(*httpproxy.FromEnvironment(), env)
+	return config.ProxyFunc()
+}
+
+// overrideProxyConfig overrides config with the proxy variables of env. Like
+// httpproxy.FromEnvironment, the uppercase name of a variable takes precedence
+// over the lowercase one, and empty values are ignored.
+func overrideProxyConfig(config httpproxy.Config, env map[string]string) httpproxy.Config {
+	for _, o := range []struct {
+		field        *string
+		upper, lower string
+	}{
+		{&config.HTTPSProxy, "HTTPS_PROXY", "https_proxy"},
+		{&config.HTTPProxy, "HTTP_PROXY", "http_proxy"},
+		{&config.NoProxy, "NO_PROXY", "no_proxy"},
+	} {
+		if v := env[o.upper]; v != "" {
+			*o.field = v
+		} else if v := env[o.lower]; v != "" {
+			*o.field = v
+		}
+	}
+	return config
+}
+
+type NetworkCheck struct {
+	// Proxy returns the proxy of each request. If nil, the proxy settings of the
+	// subagent that makes each request are used.
+	Proxy func(*url.URL) (*url.URL, error)
+	// TLSClientConfig is the TLS configuration of the requests. If nil, the
+	// default configuration is used.
+	TLSClientConfig *tls.Config
+}
 
 func (c NetworkCheck) Name() string {
 	return "Network Check"
@@ -110,6 +225,18 @@ func (c NetworkCheck) RunCheck(logger logs.StructuredLogger) error {
 		requests = append(requests, gceRequests...)
 	}
 
+	proxies := map[string]func(*url.URL) (*url.URL, error){}
+	for _, r := range requests {
+		if _, ok := proxies[r.subagent]; ok {
+			continue
+		}
+		if c.Proxy != nil {
+			proxies[r.subagent] = c.Proxy
+		} else {
+			proxies[r.subagent] = proxyFromEnvironment(logger, r.subagent)
+		}
+	}
+
 	networkErrors := make([]error, len(requests))
 	var wg sync.WaitGroup
 
@@ -117,7 +244,7 @@ func (c NetworkCheck) RunCheck(logger logs.StructuredLogger) error {
 		wg.Add(1)
 		go func(index int, req networkRequest) {
 			defer wg.Done()
-			networkErrors[index] = req.SendRequest(logger)
+			networkErrors[index] = req.SendRequest(logger, proxies[req.subagent], c.TLSClientConfig)
 		}(i, r)
 	}
 
@@ -0,0 +1,68 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthchecks
+
+import (
+	"testing"
+
+	"golang.org/x/net/http/httpproxy"
+	"gotest.tools/v3/assert"
+)
+
+func TestOverrideProxyConfig(t *testing.T) {
+	base := httpproxy.Config{
+		HTTPSProxy: "http://base:3128",
+		HTTPProxy:  "http://base:3128",
+		NoProxy:    "base",
+	}
+	for _, tc := range []struct {
+		name string
+		env  map[string]string
+		want httpproxy.Config
+	}{
+		{
+			name: "no settings",
+			want: base,
+		},
+		{
+			name: "uppercase",
+			env:  map[string]string{"HTTPS_PROXY": "http://unit:3128", "NO_PROXY": "unit"},
+			want: httpproxy.Config{HTTPSProxy: "http://unit:3128", HTTPProxy: "http://base:3128", NoProxy: "unit"},
+		},
+		{
+			name: "lowercase",
+			env:  map[string]string{"https_proxy": "http://lower:3128", "http_proxy": "http://lower:3128", "no_proxy": "lower"},
+			want: httpproxy.Config{HTTPSProxy: "http://lower:3128", HTTPProxy: "http://lower:3128", NoProxy: "lower"},
+		},
+		{
+			name: "uppercase before lowercase",
+			env: map[string]string{
+				"HTTPS_PROXY": "http://upper:3128", "https_proxy": "http://lower:3128",
+				"HTTP_PROXY": "http://upper:3128", "http_proxy": "http://lower:3128",
+				"NO_PROXY": "upper", "no_proxy": "lower",
+			},
+			want: httpproxy.Config{HTTPSProxy: "http://upper:3128", HTTPProxy: "http://upper:3128", NoProxy: "upper"},
+		},
+		{
+			name: "empty uppercase",
+			env:  map[string]string{"HTTPS_PROXY": "", "https_proxy": "http://lower:3128"},
+			want: httpproxy.Config{HTTPSProxy: "http://lower:3128", HTTPProxy: "http://base:3128", NoProxy: "base"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.DeepEqual(t, overrideProxyConfig(base, tc.env), tc.want)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthchecks
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/ops-agent/internal/logs"
+)
+
+// resultSeverity orders the results of a HealthCheckReport from best to worst.
+var resultSeverity = map[string]int{
+	"PASS":    0,
+	"WARNING": 1,
+	"ERROR":   2,
+	"FAIL":    3,
+}
+
+// Status returns the worst result among the reports of the health check.
+func (r HealthCheckResult) Status() string {
+	status := "PASS"
+	for _, report := range r.Reports() {
+		if resultSeverity[report.Result] > resultSeverity[status] {
+			status = report.Result
+		}
+	}
+	return status
+}
+
+// Select returns the health checks of the registry with the given names. Names
+// are matched case-insensitively and may omit the " Check" suffix, e.g.
+// "network" selects the "Network Check".
+func (r HealthCheckRegistry) Select(names ...string) (HealthCheckRegistry, error) {
+	var selected HealthCheckRegistry
+	for _, name := range names {
+		found := false
+		for _, c := range r {
+			if strings.EqualFold(name, c.Name()) || strings.EqualFold(name+" Check", c.Name()) {
+				selected = append(selected, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown health check %q", name)
+		}
+	}
+	return selected, nil
+}
+
+// SelectResults returns the results of the health checks of the registry, e.g.
+// to compare the startup results with the results of the selected checks.
+func (r HealthCheckRegistry) SelectResults(results []HealthCheckResult) []HealthCheckResult {
+	var selected []HealthCheckResult
+	for _, result := range results {
+		for _, c := range r {
+			if result.Name == c.Name() {
+				selected = append(selected, result)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// Defaults of the health checks that are re-run while the Ops Agent is running.
+const (
+	DefaultPeriodicInterval   = 30 * time.Minute
+	DefaultPeriodicJitter     = 3 * time.Minute
+	DefaultPeriodicMaxBackoff = 4 * time.Hour
+)
+
+// DefaultPeriodicHealthChecks returns the health checks that are re-run while the
+// Ops Agent is running. The Ports Check is left out because the running subagents
+// hold the ports that it checks.
+func DefaultPeriodicHealthChecks() HealthCheckRegistry {
+	return HealthCheckRegistry{
+		NetworkCheck{},
+		APICheck{},
+	}
+}
+
+// RunDefaultPeriodicHealthChecks re-runs the DefaultPeriodicHealthChecks with the
+// default interval, jitter and backoff until ctx is done. writeStatus is called
+// with the startup results of those checks, and then with the results of every
+// run; the errors that it returns are logged.
+func RunDefaultPeriodicHealthChecks(ctx context.Context, logger logs.StructuredLogger, startupResults []HealthCheckResult, writeStatus func([]HealthCheckResult) error) {
+	checks := DefaultPeriodicHealthChecks()
+	onResults := func(results []HealthCheckResult) {
+		if err := writeStatus(results); err != nil {
+			logger.Errorf("%v", err)
+		}
+	}
+	// Only report the checks that are re-run, so that the series of the
+	// health check status metric don't change after the first interval.
+	startupResults = checks.SelectResults(startupResults)
+	onResults(startupResults)
+	PeriodicHealthChecks{
+		Registry:   checks,
+		Interval:   DefaultPeriodicInterval,
+		Jitter:     DefaultPeriodicJitter,
+		MaxBackoff: DefaultPeriodicMaxBackoff,
+		OnResults:  onResults,
+	}.Run(ctx, logger, startupResults)
+}
+
+// PeriodicHealthChecks re-runs a registry of health checks until its context is done.
+type PeriodicHealthChecks struct {
+	Registry HealthCheckRegistry
+	// Interval is the time between two runs while every check passes.
+	Interval time.Duration
+	// Jitter is the maximum random delay added to every interval.
+	Jitter time.Duration
+	// MaxBackoff caps the interval, which doubles after every run where a check
+	// doesn't pass, until all of them pass again. The interval never grows if
+	// MaxBackoff is not greater than Interval.
+	MaxBackoff time.Duration
+	// OnResults, if set, is called with the results of every run.
+	OnResults func([]HealthCheckResult)
+}
+
+// Run waits for an interval, runs the health checks, logs their results and the
+// checks whose status changed since the previous run, and repeats until ctx is done.
+// The first run is compared against the results of the startup health checks.
+func (p PeriodicHealthChecks) Run(ctx context.Context, logger logs.StructuredLogger, startupResults []HealthCheckResult) {
+	statuses := map[string]string{}
+	for _, r := range startupResults {
+		statuses[r.Name] = r.Status()
+	}
+	interval := p.Interval
+	for {
+		timer := time.NewTimer(p.delay(interval))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		results := p.Registry.RunAllHealthChecks(logger)
+		healthy := true
+		for _, r := range results {
+			status := r.Status()
+			if previous, ok := statuses[r.Name]; ok && previous != status {
+				logger.Infof("[%s] Status changed from %s to %s", r.Name, previous, status)
+			}
+			statuses[r.Name] = status
+			if status != "PASS" {
+				healthy = false
+			}
+		}
+		if p.OnResults != nil {
+			p.OnResults(results)
+		}
+
+		interval = p.nextInterval(interval, healthy)
+	}
+}
+
+// delay adds a random jitter to the interval.
+func (p PeriodicHealthChecks) delay(interval time.Duration) time.Duration {
+	if p.Jitter > 0 {
+		interval += time.Duration(rand.Int63n(int64(p.Jitter)))
+	}
+	return interval
+}
+
+// nextInterval returns the interval that follows interval after a run.
+func (p PeriodicHealthChecks) nextInterval(interval time.Duration, healthy bool) time.Duration {
+	if healthy || p.MaxBackoff <= p.Interval {
+		return p.Interval
+	}
+	return min(2*interval, p.MaxBackoff)
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthchecks
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestPeriodicHealthChecksBackoff(t *testing.T) {
+	p := PeriodicHealthChecks{
+		Interval:   time.Minute,
+		Jitter:     10 * time.Second,
+		MaxBackoff: 5 * time.Minute,
+	}
+
+	var intervals []time.Duration
+	interval := p.Interval
+	for _, healthy := range []bool{false, false, false, false, true, false} {
+		interval = p.nextInterval(interval, healthy)
+		intervals = append(intervals, interval)
+	}
+	// The interval doubles while a check fails, is capped at MaxBackoff, and
+	// is reset once all checks pass.
+	assert.DeepEqual(t, intervals, []time.Duration{
+		2 * time.Minute,
+		4 * time.Minute,
+		5 * time.Minute,
+		5 * time.Minute,
+		time.Minute,
+		2 * time.Minute,
+	})
+
+	for range 100 {
+		delay := p.delay(interval)
+		assert.Assert(t, delay >= interval && delay < interval+p.Jitter, "delay %s out of range", delay)
+	}
+}
+
+func TestPeriodicHealthChecksNoBackoff(t *testing.T) {
+	p := PeriodicHealthChecks{Interval: time.Minute}
+	assert.Equal(t, p.nextInterval(time.Minute, false), time.Minute)
+	assert.Equal(t, p.delay(time.Minute), time.Minute)
+}
@@ -18,8 +18,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/fluentbit"
@@ -39,13 +41,19 @@ func (c PortsCheck) Name() string {
 	return "Ports Check"
 }
 
-// checkIfPortAvailable listens in the provided socket and local provided network (tcp4, tcp6, ...)
+// checkIfPortAvailable listens in the provided socket and local provided network (tcp4, tcp6, udp, ...)
 // and handles the errors if the port is already being used by another process.
 func checkIfPortAvailable(host string, port string, network string) (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	var lc net.ListenConfig
-	lsnr, err := lc.Listen(ctx, network, net.JoinHostPort(host, port))
+	var lsnr io.Closer
+	var err error
+	if strings.HasPrefix(network, "udp") {
+		lsnr, err = lc.ListenPacket(ctx, network, net.JoinHostPort(host, port))
+	} else {
+		lsnr, err = lc.Listen(ctx, network, net.JoinHostPort(host, port))
+	}
 	if err != nil {
 		if isPortUnavailableError(err) {
 			return false, nil
@@ -0,0 +1,218 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthchecks
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/fluentbit"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/portutil"
+	"github.com/GoogleCloudPlatform/ops-agent/internal/logs"
+)
+
+const receiverDialTimeout = 5 * time.Second
+
+// A ListenAddress is the transport protocol, host and port that a receiver listens on.
+type ListenAddress struct {
+	// Protocol is "tcp" or "udp".
+	Protocol string
+	// Host is the IP address or hostname to listen on. An unspecified address listens on all of them.
+	Host string
+	Port uint16
+}
+
+// overlaps returns true if a and b cannot be listened on at the same time.
+func (a ListenAddress) overlaps(b ListenAddress) bool {
+	if a.Protocol != b.Protocol || a.Port != b.Port {
+		return false
+	}
+	return a.Host == b.Host || isUnspecifiedHost(a.Host) || isUnspecifiedHost(b.Host)
+}
+
+func (a ListenAddress) String() string {
+	return fmt.Sprintf("%s port %d", strings.ToUpper(a.Protocol), a.Port)
+}
+
+func isUnspecifiedHost(host string) bool {
+	if host == "" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsUnspecified()
+}
+
+// ReceiversCheck verifies the ports and endpoints used by the receivers of the
+// merged agent config.
+type ReceiversCheck struct {
+	// ListenAddresses maps the IDs of receivers that listen on a port of the host to their address.
+	ListenAddresses map[string]ListenAddress
+	// TargetAddresses maps the IDs of receivers that connect to an application to its host:port.
+	TargetAddresses map[string]string
+}
+
+func (c ReceiversCheck) Name() string {
+	return "Receivers Check"
+}
+
+func (c ReceiversCheck) RunCheck(logger logs.StructuredLogger) error {
+	errs := c.runListenPortsCheck(logger)
+	errs = append(errs, c.runTargetAddressesCheck(logger)...)
+	return errors.Join(errs...)
+}
+
+// groupListenAddresses groups the receivers whose listen addresses overlap.
+// Each group is sorted, and the groups are sorted by port and by receiver ID.
+func groupListenAddresses(addresses map[string]ListenAddress) [][]string {
+	rIDs := make([]string, 0, len(addresses))
+	for rID := range addresses {
+		rIDs = append(rIDs, rID)
+	}
+	sort.Strings(rIDs)
+	grouped := map[string]bool{}
+	var groups [][]string
+	for _, rID := range rIDs {
+		if grouped[rID] {
+			continue
+		}
+		group := []string{rID}
+		grouped[rID] = true
+		// An unspecified host overlaps with other hosts that do not overlap with each other,
+		// so keep adding the receivers that overlap with any receiver of the group.
+		for i := 0; i < len(group); i++ {
+			for _, other := range rIDs {
+				if !grouped[other] && addresses[group[i]].overlaps(addresses[other]) {
+					group = append(group, other)
+					grouped[other] = true
+				}
+			}
+		}
+		sort.Strings(group)
+		groups = append(groups, group)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return addresses[groups[i][0]].Port < addresses[groups[j][0]].Port
+	})
+	return groups
+}
+
+// runListenPortsCheck reports receivers that share a port with another receiver
+// or with the agent self metrics, and probes every other port for availability.
+func (c ReceiversCheck) runListenPortsCheck(logger logs.StructuredLogger) []error {
+	selfMetricsPorts := map[uint16]bool{
+		portutil.GetPortFromEnv(fluentbit.ExperimentalMetricsPortEnv, fluentbit.MetricsPort): true,
+		portutil.GetPortFromEnv(otel.ExperimentalMetricsPortEnv, otel.MetricsPort):           true,
+	}
+
+	var errs []error
+	var available []string
+	for _, rIDs := range groupListenAddresses(c.ListenAddresses) {
+		address := c.ListenAddresses[rIDs[0]]
+		switch {
+		case len(rIDs) > 1:
+			hcErr := ReceiverPortConflictErr
+			hcErr.Message = fmt.Sprintf("Receivers %s are all configured to listen on %s.", strings.Join(quoteAll(rIDs), ", "), address)
+			hcErr.Action = fmt.Sprintf("Configure receivers %s to listen on different ports.", strings.Join(quoteAll(rIDs), ", "))
+			errs = append(errs, hcErr)
+		case address.Protocol == "tcp" && selfMetricsPorts[address.Port]:
+			hcErr := ReceiverPortConflictErr
+			hcErr.Message = fmt.Sprintf("Receiver %q is configured to listen on %s, which is needed for Ops Agent self metrics.", rIDs[0], address)
+			hcErr.Action = fmt.Sprintf("Configure receiver %q to listen on a different port.", rIDs[0])
+			errs = append(errs, hcErr)
+		default:
+			available = append(available, rIDs[0])
+		}
+	}
+	if len(available) == 0 {
+		return errs
+	}
+
+	// A running subagent holds the ports of its own receivers.
+	for _, subagent := range []string{"google-cloud-ops-agent-fluent-bit", "google-cloud-ops-agent-opentelemetry-collector"} {
+		active, err := isSubagentActive(subagent)
+		if err != nil {
+			return append(errs, err)
+		}
+		if active {
+			return errs
+		}
+	}
+
+	for _, rID := range available {
+		address := c.ListenAddresses[rID]
+		ok, err := checkIfPortAvailable(address.Host, strconv.Itoa(int(address.Port)), address.Protocol)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !ok {
+			hcErr := ReceiverPortUnavailableErr
+			hcErr.Message = fmt.Sprintf("%s needed by receiver %q is unavailable.", address, rID)
+			hcErr.Action = fmt.Sprintf("Verify that %s is not used by another process, or configure receiver %q to listen on a different port.", address, rID)
+			errs = append(errs, hcErr)
+			continue
+		}
+		logger.Infof("%s needed by receiver %q is available", address, rID)
+	}
+	return errs
+}
+
+// runTargetAddressesCheck verifies that every receiver can open a TCP connection
+// to the application that it collects telemetry from.
+func (c ReceiversCheck) runTargetAddressesCheck(logger logs.StructuredLogger) []error {
+	rIDs := make([]string, 0, len(c.TargetAddresses))
+	for rID := range c.TargetAddresses {
+		rIDs = append(rIDs, rID)
+	}
+	sort.Strings(rIDs)
+
+	endpointErrors := make([]error, len(rIDs))
+	var wg sync.WaitGroup
+
+	for i, rID := range rIDs {
+		wg.Add(1)
+		go func(index int, rID, address string) {
+			defer wg.Done()
+			conn, err := net.DialTimeout("tcp", address, receiverDialTimeout)
+			if err != nil {
+				hcErr := ReceiverEndpointConnErr
+				hcErr.Message = fmt.Sprintf("Receiver %q could not connect to %s.", rID, address)
+				hcErr.Action = fmt.Sprintf("Verify that the application monitored by receiver %q is running and reachable at %s.", rID, address)
+				endpointErrors[index] = hcErr
+				return
+			}
+			conn.Close()
+			logger.Infof("receiver %q connected to %s", rID, address)
+		}(i, rID, c.TargetAddresses[rID])
+	}
+
+	wg.Wait()
+	return endpointErrors
+}
+
+func quoteAll(s []string) []string {
+	out := make([]string, len(s))
+	for i, v := range s {
+		out[i] = strconv.Quote(v)
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthchecks
+
+import (
+	"net"
+	"strconv"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestGroupListenAddresses(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		addresses map[string]ListenAddress
+		want      [][]string
+	}{
+		{
+			name: "tcp and udp on the same port",
+			addresses: map[string]ListenAddress{
+				"syslog_tcp": {Protocol: "tcp", Host: "0.0.0.0", Port: 5140},
+				"syslog_udp": {Protocol: "udp", Host: "0.0.0.0", Port: 5140},
+			},
+			want: [][]string{{"syslog_tcp"}, {"syslog_udp"}},
+		},
+		{
+			name: "different hosts",
+			addresses: map[string]ListenAddress{
+				"a": {Protocol: "tcp", Host: "127.0.0.1", Port: 5170},
+				"b": {Protocol: "tcp", Host: "10.0.0.1", Port: 5170},
+			},
+			want: [][]string{{"a"}, {"b"}},
+		},
+		{
+			name: "unspecified host overlaps every host",
+			addresses: map[string]ListenAddress{
+				"a":   {Protocol: "tcp", Host: "127.0.0.1", Port: 5170},
+				"b":   {Protocol: "tcp", Host: "10.0.0.1", Port: 5170},
+				"all": {Protocol: "tcp", Host: "::", Port: 5170},
+			},
+			want: [][]string{{"a", "all", "b"}},
+		},
+		{
+			name: "sorted by port",
+			addresses: map[string]ListenAddress{
+				"a": {Protocol: "udp", Host: "0.0.0.0", Port: 5140},
+				"b": {Protocol: "tcp", Host: "127.0.0.1", Port: 24224},
+				"c": {Protocol: "tcp", Host: "127.0.0.1", Port: 5170},
+				"d": {Protocol: "udp", Port: 5140},
+			},
+			want: [][]string{{"a", "d"}, {"c"}, {"b"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.DeepEqual(t, groupListenAddresses(tc.addresses), tc.want)
+		})
+	}
+}
+
+func TestCheckIfPortAvailableUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer conn.Close()
+	port := strconv.Itoa(conn.LocalAddr().(*net.UDPAddr).Port)
+
+	available, err := checkIfPortAvailable("127.0.0.1", port, "udp")
+	assert.NilError(t, err)
+	assert.Check(t, !available, "UDP port %s is in use", port)
+
+	available, err = checkIfPortAvailable("127.0.0.1", port, "tcp")
+	assert.NilError(t, err)
+	assert.Check(t, available, "TCP port %s is free", port)
+}
@@ -36,6 +36,12 @@ func isSubagentActive(subagent string) (bool, error) {
 	return strings.TrimSpace(string(output)) == "Running", nil
 }
 
+// subagentEnvironment returns no settings, since Windows services read the
+// environment of the system.
+func subagentEnvironment(subagent string) (map[string]string, error) {
+	return nil, nil
+}
+
 func isPortUnavailableError(err error) bool {
 	return errors.Is(err, windows.WSAEADDRINUSE) || errors.Is(err, windows.WSAEACCES)
 }
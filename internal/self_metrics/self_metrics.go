@@ -18,12 +18,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	mexporter "github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/metric"
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
+	"github.com/GoogleCloudPlatform/ops-agent/internal/healthchecks"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/contrib/detectors/gcp"
 	"go.opentelemetry.io/otel/attribute"
@@ -36,9 +38,10 @@ import (
 )
 
 const (
-	agentMetricNamespace       string = "agent.googleapis.com"
-	enabledReceiversMetricName string = "agent/ops_agent/enabled_receivers"
-	featureTrackingMetricName  string = "agent/internal/ops/feature_tracking"
+	agentMetricNamespace        string = "agent.googleapis.com"
+	enabledReceiversMetricName  string = "agent/ops_agent/enabled_receivers"
+	featureTrackingMetricName   string = "agent/internal/ops/feature_tracking"
+	healthCheckStatusMetricName string = "agent/ops_agent/health_check_status"
 )
 
 func getFullAgentMetricName(metricName string) string {
@@ -332,6 +335,50 @@ func CollectFeatureTrackingMetricToOTLPJSON(ctx context.Context, userUc, mergedU
 	return metricToJson(metrics)
 }
 
+// CollectHealthCheckStatusMetricToOTLPJSON returns a gauge with a point for every
+// report of the health check results.
+func CollectHealthCheckStatusMetricToOTLPJSON(results []healthchecks.HealthCheckResult) ([]byte, error) {
+	metrics := pmetric.NewMetrics()
+	resource := metrics.ResourceMetrics().AppendEmpty()
+	resource.Resource().Attributes().PutStr("k", "v") // Resources can't be empty
+
+	gaugeMetric := resource.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	gaugeMetric.SetName(getFullAgentMetricName(healthCheckStatusMetricName))
+	dataPoints := gaugeMetric.SetEmptyGauge().DataPoints()
+
+	for _, r := range results {
+		for _, report := range r.Reports() {
+			point := dataPoints.AppendEmpty()
+			point.SetIntValue(int64(1))
+			attributes := point.Attributes()
+			attributes.PutStr("check", report.Name)
+			attributes.PutStr("result", report.Result)
+			attributes.PutStr("code", report.Code)
+		}
+	}
+
+	return metricToJson(metrics)
+}
+
+// WriteHealthCheckStatusOTLPJSON writes the health check status metric to outDir,
+// where it is picked up by the otlpjsonfile receiver of the Ops Agent self metrics.
+// The receiver polls the file while the agent runs, so the file is replaced by a
+// rename instead of being rewritten in place.
+func WriteHealthCheckStatusOTLPJSON(results []healthchecks.HealthCheckResult, outDir string) error {
+	healthCheckStatusOTLPJSON, err := CollectHealthCheckStatusMetricToOTLPJSON(results)
+	if err != nil {
+		return fmt.Errorf("failed to generate health check status metric otlp json: %w", err)
+	}
+	path := filepath.Join(outDir, "health_checks_otlp.json")
+	if err = confgenerator.WriteConfigFile(healthCheckStatusOTLPJSON, path+".tmp"); err != nil {
+		return fmt.Errorf("failed to write health check status metric otlp json file: %w", err)
+	}
+	if err = os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to replace health check status metric otlp json file: %w", err)
+	}
+	return nil
+}
+
 // config and merged config respectively
 func getUserAndMergedConfigs(ctx context.Context, userConfPath string) (*confgenerator.UnifiedConfig, *confgenerator.UnifiedConfig, error) {
 	userUc, err := confgenerator.ReadUnifiedConfigFromFile(ctx, userConfPath)
@@ -16,13 +16,18 @@ package self_metrics_test
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/ops-agent/apps"
 	_ "github.com/GoogleCloudPlatform/ops-agent/apps"
 	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
 	"github.com/GoogleCloudPlatform/ops-agent/internal/experiments"
+	"github.com/GoogleCloudPlatform/ops-agent/internal/healthchecks"
 	"github.com/GoogleCloudPlatform/ops-agent/internal/self_metrics"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"gotest.tools/v3/assert"
 )
 
@@ -91,3 +96,51 @@ func TestEnabledReceiversDefaultConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthCheckStatusMetric(t *testing.T) {
+	results := []healthchecks.HealthCheckResult{
+		{Name: "Network Check", Err: errors.Join(healthchecks.LogApiConnErr, healthchecks.PacApiConnErr)},
+		{Name: "API Check"},
+	}
+	b, err := self_metrics.CollectHealthCheckStatusMetricToOTLPJSON(results)
+	assert.NilError(t, err)
+
+	metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(b)
+	assert.NilError(t, err)
+	metric := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, metric.Name(), "agent.googleapis.com/agent/ops_agent/health_check_status")
+
+	var got []map[string]any
+	points := metric.Gauge().DataPoints()
+	for i := 0; i < points.Len(); i++ {
+		assert.Equal(t, points.At(i).IntValue(), int64(1))
+		got = append(got, points.At(i).Attributes().AsRaw())
+	}
+	assert.DeepEqual(t, got, []map[string]any{
+		{"check": "Network Check", "result": "FAIL", "code": "LogApiConnErr"},
+		{"check": "Network Check", "result": "WARNING", "code": "PacApiConnErr"},
+		{"check": "API Check", "result": "PASS", "code": ""},
+	})
+}
+
+func TestWriteHealthCheckStatusOTLPJSON(t *testing.T) {
+	dir := t.TempDir()
+	for _, results := range [][]healthchecks.HealthCheckResult{
+		{{Name: "Network Check", Err: healthchecks.LogApiConnErr}},
+		{{Name: "Network Check"}},
+	} {
+		assert.NilError(t, self_metrics.WriteHealthCheckStatusOTLPJSON(results, dir))
+	}
+
+	// The file is replaced, and no temporary file is left next to it.
+	entries, err := os.ReadDir(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 1)
+	assert.Equal(t, entries[0].Name(), "health_checks_otlp.json")
+	b, err := os.ReadFile(filepath.Join(dir, "health_checks_otlp.json"))
+	assert.NilError(t, err)
+	metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(b)
+	assert.NilError(t, err)
+	point := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+	assert.Equal(t, point.Attributes().AsRaw()["result"], "PASS")
+}
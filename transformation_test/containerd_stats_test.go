@@ -0,0 +1,94 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformation_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/ops-agent/apps"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
+)
+
+// fakeContainerdMetrics serves the cgroup v2 stats of a single container, like the metrics endpoint
+// of containerd, and returns its host:port.
+func fakeContainerdMetrics(t *testing.T) string {
+	t.Helper()
+	metrics := fmt.Sprintf(`# TYPE container_cpu_usage_usec_microseconds gauge
+container_cpu_usage_usec_microseconds{container_id=%[1]q,namespace="default"} 2e+06
+# TYPE container_memory_usage_bytes gauge
+container_memory_usage_bytes{container_id=%[1]q,namespace="default"} 1.048576e+08
+# TYPE container_io_rbytes_bytes gauge
+container_io_rbytes_bytes{container_id=%[1]q,major="8",minor="0",namespace="default"} 4096
+# TYPE container_pids_current gauge
+container_pids_current{container_id=%[1]q,namespace="default"} 3
+# TYPE go_goroutines gauge
+go_goroutines 42
+`, fakeContainerID)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/metrics" {
+			t.Logf("unexpected containerd metrics request: %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.Write([]byte(metrics))
+	}))
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+func TestContainerdStats(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext(), time.Minute)
+	defer cancel()
+
+	r := apps.MetricsReceiverContainerdStats{
+		MetricsReceiverShared: confgenerator.MetricsReceiverShared{CollectionInterval: "10s"},
+		Endpoint:              fakeContainerdMetrics(t),
+	}
+	receiverPipelines, err := r.Pipelines(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := firstTimeSeriesRequest(ctx, t, r.Type(), receiverPipelines[0])
+
+	metrics := map[string]map[string]string{}
+	for _, ts := range request.GetTimeSeries() {
+		metrics[ts.GetMetric().GetType()] = ts.GetMetric().GetLabels()
+	}
+	for _, metric := range []string{
+		"workload.googleapis.com/container_cpu_usage_usec_microseconds",
+		"workload.googleapis.com/container_memory_usage_bytes",
+		"workload.googleapis.com/container_io_rbytes_bytes",
+		"workload.googleapis.com/container_pids_current",
+	} {
+		labels, ok := metrics[metric]
+		if !ok {
+			t.Errorf("metric %q not exported; got %v", metric, metrics)
+			continue
+		}
+		if labels["container_id"] != fakeContainerID || labels["namespace"] != "default" {
+			t.Errorf("metric %q has labels %v, want container_id %q and namespace %q", metric, labels, fakeContainerID, "default")
+		}
+	}
+	if _, ok := metrics["workload.googleapis.com/go_goroutines"]; ok {
+		t.Errorf("the metrics of containerd itself are exported; got %v", metrics)
+	}
+}
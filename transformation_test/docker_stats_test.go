@@ -0,0 +1,216 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformation_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"github.com/GoogleCloudPlatform/ops-agent/apps"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator"
+	"github.com/GoogleCloudPlatform/ops-agent/confgenerator/otel"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	fakeContainerID    = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	fakeContainerName  = "web"
+	fakeContainerImage = "nginx:latest"
+)
+
+var dockerAPIVersionPrefix = regexp.MustCompile(`^/v[0-9.]+/`)
+
+// fakeDockerAPI serves the Docker Engine API requests of the docker_stats receiver
+// on a Unix socket with a single running container, and returns the path of the socket.
+func fakeDockerAPI(t *testing.T) string {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "docker.sock")
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	container := fmt.Sprintf(`{"Id": %q, "Names": ["/%s"], "Image": %q, "State": "running"}`, fakeContainerID, fakeContainerName, fakeContainerImage)
+	inspect := fmt.Sprintf(`{"Id": %q, "Name": "/%s", "Image": "sha256:0123", "State": {"Running": true}, "Config": {"Hostname": %q, "Image": %q, "Env": [], "Labels": {}}, "HostConfig": {}}`,
+		fakeContainerID, fakeContainerName, fakeContainerName, fakeContainerImage)
+	stats := `{
+		"read": "2026-01-01T00:00:10Z",
+		"preread": "2026-01-01T00:00:00Z",
+		"cpu_stats": {"cpu_usage": {"total_usage": 2000000000, "usage_in_kernelmode": 500000000, "usage_in_usermode": 1500000000, "percpu_usage": [2000000000]}, "system_cpu_usage": 20000000000, "online_cpus": 1},
+		"precpu_stats": {"cpu_usage": {"total_usage": 1000000000, "usage_in_kernelmode": 250000000, "usage_in_usermode": 750000000, "percpu_usage": [1000000000]}, "system_cpu_usage": 10000000000, "online_cpus": 1},
+		"memory_stats": {"usage": 104857600, "limit": 1073741824, "stats": {"total_cache": 0}},
+		"networks": {"eth0": {"rx_bytes": 1024, "rx_packets": 8, "tx_bytes": 2048, "tx_packets": 16}},
+		"blkio_stats": {}
+	}`
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := dockerAPIVersionPrefix.ReplaceAllString(r.URL.Path, "/")
+		w.Header().Set("Content-Type", "application/json")
+		switch path {
+		case "/_ping":
+			w.Write([]byte("OK"))
+		case "/version":
+			w.Write([]byte(`{"Version": "24.0.0", "ApiVersion": "1.43", "MinAPIVersion": "1.12"}`))
+		case "/containers/json":
+			w.Write([]byte("[" + container + "]"))
+		case "/containers/" + fakeContainerID + "/json":
+			w.Write([]byte(inspect))
+		case "/containers/" + fakeContainerID + "/stats":
+			w.Write([]byte(stats))
+		case "/events":
+			// Keep the event stream open until the receiver disconnects.
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		default:
+			t.Logf("unexpected Docker API request: %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	})}
+	go srv.Serve(ln)
+	t.Cleanup(func() { srv.Close() })
+	return socket
+}
+
+type mockMetricServer struct {
+	monitoringpb.UnimplementedMetricServiceServer
+	requestCh chan<- *monitoringpb.CreateTimeSeriesRequest
+}
+
+func (s *mockMetricServer) CreateTimeSeries(ctx context.Context, request *monitoringpb.CreateTimeSeriesRequest) (*emptypb.Empty, error) {
+	select {
+	case s.requestCh <- request:
+	default:
+		// The test only needs the first request.
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func TestDockerStatsUnixSocket(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext(), time.Minute)
+	defer cancel()
+
+	socket := fakeDockerAPI(t)
+	r := apps.MetricsReceiverDockerStats{
+		MetricsReceiverShared: confgenerator.MetricsReceiverShared{CollectionInterval: "10s"},
+		Endpoint:              "unix://" + socket,
+	}
+	if got := r.GetTargetAddress(); got != "" {
+		t.Errorf("GetTargetAddress() = %q, want no TCP address for a Unix socket", got)
+	}
+	receiverPipelines, err := r.Pipelines(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := firstTimeSeriesRequest(ctx, t, r.Type(), receiverPipelines[0])
+
+	metrics := map[string]map[string]string{}
+	for _, ts := range request.GetTimeSeries() {
+		metrics[ts.GetMetric().GetType()] = ts.GetMetric().GetLabels()
+	}
+	for _, metric := range []string{
+		"workload.googleapis.com/container.cpu.usage.total",
+		"workload.googleapis.com/container.memory.usage.total",
+	} {
+		labels, ok := metrics[metric]
+		if !ok {
+			t.Errorf("metric %q not exported; got %v", metric, metrics)
+			continue
+		}
+		if labels["container_id"] != fakeContainerID || labels["container_name"] != fakeContainerName {
+			t.Errorf("metric %q has labels %v, want container_id %q and container_name %q", metric, labels, fakeContainerID, fakeContainerName)
+		}
+	}
+}
+
+// firstTimeSeriesRequest runs otelopscol with receiverPipeline, and returns the first request
+// that it sends to Cloud Monitoring. It skips the test if --otelopscol is not supplied.
+func firstTimeSeriesRequest(ctx context.Context, t *testing.T, name string, receiverPipeline otel.ReceiverPipeline) *monitoringpb.CreateTimeSeriesRequest {
+	t.Helper()
+	ln, err := net.Listen("tcp", "localhost:")
+	if err != nil {
+		t.Fatalf("Failed to find an available address to run the gRPC server: %v", err)
+	}
+	requestCh := make(chan *monitoringpb.CreateTimeSeriesRequest, 1)
+	srv := grpc.NewServer()
+	monitoringpb.RegisterMetricServiceServer(srv, &mockMetricServer{requestCh: requestCh})
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+
+	config, err := otel.ModularConfig{
+		DisableMetrics:    true,
+		JSONLogs:          true,
+		LogLevel:          "debug",
+		ReceiverPipelines: map[string]otel.ReceiverPipeline{name: receiverPipeline},
+		Pipelines: map[string]otel.Pipeline{name: {
+			Type:                 "metrics",
+			ReceiverPipelineName: name,
+		}},
+		Exporters: map[otel.ExporterType]otel.ExporterComponents{
+			otel.OTel: {
+				Exporter: otel.Component{
+					Type: "googlecloud",
+					Config: map[string]any{
+						"project": "my-project",
+						"metric": map[string]any{
+							"prefix":                 "",
+							"skip_create_descriptor": true,
+							"endpoint":               ln.Addr().String(),
+							"use_insecure":           true,
+						},
+					},
+				},
+			},
+		},
+	}.Generate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("otelopscol config:\n%s", config)
+
+	if len(*otelopscolPath) == 0 {
+		t.Skip("--otelopscol not supplied")
+	}
+
+	cmd := exec.CommandContext(ctx, *otelopscolPath, "--config=env:OTELOPSCOL_CONFIG")
+	cmd.Env = append(os.Environ(), fmt.Sprintf("OTELOPSCOL_CONFIG=%s", config))
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		t.Fatal("Failed to start command:", err)
+	}
+	defer func() {
+		cmd.Process.Signal(os.Interrupt)
+		cmd.Wait()
+		t.Logf("collector stderr:\n%s", stderr.String())
+	}()
+
+	select {
+	case request := <-requestCh:
+		return request
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for the %s metrics", name)
+		return nil
+	}
+}